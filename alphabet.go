@@ -0,0 +1,195 @@
+package profaneword
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Alternative is a replacement of a letter, chosen by its Weight relative to the other alternatives of the letter
+type Alternative struct {
+	Text   string
+	Weight float64
+}
+
+// Alphabet is a 1337 alphabet, the alternatives of each letter, the letters are uppercase
+type Alphabet map[rune][]Alternative
+
+// uniform returns the alternatives of equal weight
+func uniform(texts ...string) []Alternative {
+	alternatives := make([]Alternative, len(texts))
+	for i, text := range texts {
+		alternatives[i] = Alternative{Text: text, Weight: 1}
+	}
+	return alternatives
+}
+
+// l337Alphabet is a small subset of the 1337 alphabet
+var l337Alphabet = Alphabet{
+	'A': uniform("4"),
+	'B': uniform("8"),
+	'E': uniform("3"),
+	'G': uniform("6"),
+	'I': uniform("1"),
+	'L': uniform("1"),
+	'O': uniform("0"),
+	'S': uniform("5"),
+	'T': uniform("7"),
+	'Z': uniform("2"),
+}
+
+// uber1337Alphabet is curated from https://da.wikipedia.org/wiki/Leetspeak
+var uber1337Alphabet = Alphabet{
+	'A': uniform("4", `/\`, "@", `/-\`),
+	'B': uniform("8", "13", "|3", "!3"),
+	'C': uniform("[", "(", "<"),
+	'D': uniform(")", "[)"),
+	'E': uniform("3"),
+	'F': uniform("|=", "|#"),
+	'G': uniform("6", "(_+"),
+	'H': uniform("#", "]-[", "|-|"),
+	'I': uniform("1", "!", "|"),
+	'J': uniform("_|"),
+	'K': uniform("|<"),
+	'L': uniform("1", "|_", "|"),
+	'M': uniform("|v|", `|\/|`),
+	'N': uniform(`|\|`, "|V"),
+	'O': uniform("0", "()"),
+	'P': uniform("|>"),
+	'Q': uniform("()_"),
+	'R': uniform("2", "12", "|?"),
+	'S': uniform("5", "$", "§", "z", "Z"),
+	'T': uniform("7", "+"),
+	'U': uniform("(_)", "|_|"),
+	'V': uniform(`\/`),
+	'W': uniform(`\/\/`, "vv", `'//`, `\\'`),
+	'X': uniform("><", "}{"),
+	'Y': uniform("`/"),
+	'Z': uniform("2", "~/_"),
+}
+
+// Random reports whether any letter has more than one alternative, such that formatting makes random decisions
+func (a Alphabet) Random() bool {
+	for _, alternatives := range a {
+		if len(alternatives) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// choose returns one of the alternatives by their weights, the only alternative is returned without a random decision
+func choose(device RandomDevice, alternatives []Alternative) Alternative {
+	if len(alternatives) == 1 {
+		return alternatives[0]
+	}
+	total, uniform := 0., true
+	for _, alternative := range alternatives {
+		total += alternative.Weight
+		uniform = uniform && alternative.Weight == alternatives[0].Weight
+	}
+	if uniform {
+		return alternatives[device.RandMax(len(alternatives))]
+	}
+	if recorder, ok := device.(entropyRecorder); ok {
+		recorder.AddBits(weightedEntropy(alternatives, total))
+	}
+	x := SourceOf(device).Float64() * total
+	for _, alternative := range alternatives {
+		if x -= alternative.Weight; x < 0 {
+			return alternative
+		}
+	}
+	return alternatives[len(alternatives)-1]
+}
+
+// weightedEntropy is the entropy, in bits, of choosing among the alternatives by their weights
+func weightedEntropy(alternatives []Alternative, total float64) (bits float64) {
+	for _, alternative := range alternatives {
+		if p := alternative.Weight / total; p > 0 {
+			bits -= p * math.Log2(p)
+		}
+	}
+	return
+}
+
+// ParseAlphabetJSON parses a JSON object of letters to an array of alternatives,
+// each a text or an object of the text and its weight, fx:
+//
+//	{"A": ["4", {"text": "/\\", "weight": 2}], "E": ["3"]}
+func ParseAlphabetJSON(data []byte) (Alphabet, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return alphabetOf(doc)
+}
+
+// alphabetOf returns the Alphabet of a decoded document, the letters are made uppercase.
+// An alternative given as a text has the weight 1
+func alphabetOf(doc map[string]interface{}) (Alphabet, error) {
+	alphabet := make(Alphabet, len(doc))
+	letters := make([]string, 0, len(doc))
+	for letter := range doc {
+		letters = append(letters, letter)
+	}
+	sort.Strings(letters)
+	for _, letter := range letters {
+		r, size := utf8.DecodeRuneInString(letter)
+		if size == 0 || size != len(letter) {
+			return nil, fmt.Errorf("%q is not a single letter", letter)
+		}
+		r = unicode.ToUpper(r)
+		if _, dup := alphabet[r]; dup {
+			return nil, fmt.Errorf("the letter %q is given twice", letter)
+		}
+		values, ok := doc[letter].([]interface{})
+		if !ok || len(values) == 0 {
+			return nil, fmt.Errorf("%q: expected an array of alternatives", letter)
+		}
+		for _, value := range values {
+			alternative, err := alternativeOf(value)
+			if err != nil {
+				return nil, fmt.Errorf("%q: %w", letter, err)
+			}
+			alphabet[r] = append(alphabet[r], alternative)
+		}
+	}
+	return alphabet, nil
+}
+
+// alternativeOf returns the Alternative of a text, or of an object of the text and the weight
+func alternativeOf(value interface{}) (Alternative, error) {
+	alternative := Alternative{Weight: 1}
+	switch v := value.(type) {
+	case string:
+		alternative.Text = v
+	case map[string]interface{}:
+		for key, field := range v {
+			var ok bool
+			switch key {
+			case "text":
+				alternative.Text, ok = field.(string)
+			case "weight":
+				alternative.Weight, ok = field.(float64)
+			default:
+				return alternative, fmt.Errorf("unknown field %q of an alternative", key)
+			}
+			if !ok {
+				return alternative, fmt.Errorf("invalid %s of an alternative: %v", key, field)
+			}
+		}
+	default:
+		return alternative, fmt.Errorf("expected a text, or a text and a weight, got %v", value)
+	}
+	if alternative.Text == "" {
+		return alternative, fmt.Errorf("empty alternative")
+	}
+	if !(alternative.Weight > 0) || math.IsInf(alternative.Weight, 0) {
+		return alternative, fmt.Errorf("the weight of %q must be a positive number", alternative.Text)
+	}
+	return alternative, nil
+}
@@ -0,0 +1,62 @@
+package profaneword
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAlphabet(t *testing.T) {
+	expected := Alphabet{
+		'A': {{Text: "4", Weight: 1}, {Text: `/\`, Weight: 2.5}},
+		'E': {{Text: "3", Weight: 1}},
+		'Ø': {{Text: "0/", Weight: 1}},
+	}
+	fromJSON, err := ParseAlphabetJSON([]byte(`{"a": ["4", {"text": "/\\", "weight": 2.5}], "E": ["3"], "ø": ["0/"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromJSON, expected) {
+		t.Errorf("expected %v, got %v", expected, fromJSON)
+	}
+}
+
+func TestParseAlphabet_errors(t *testing.T) {
+	tests := map[string]string{
+		`{"A": ["4"], "a": ["@"]}`:              "twice",
+		`{"AB": ["4"]}`:                         "single letter",
+		`{"A": "4"}`:                            "array of alternatives",
+		`{"A": []}`:                             "array of alternatives",
+		`{"A": [""]}`:                           "empty",
+		`{"A": [4]}`:                            "expected a text",
+		`{"A": [{"text": "4", "weight": 0}]}`:   "positive",
+		`{"A": [{"text": "4", "weight": "1"}]}`: "invalid weight",
+		`{"A": [{"txt": "4"}]}`:                 "unknown field",
+		`{"A": ["4" "@"]}`:                      "invalid character",
+	}
+	for doc, expected := range tests {
+		if _, err := ParseAlphabetJSON([]byte(doc)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q: expected an error containing %q, got %v", doc, expected, err)
+		}
+	}
+}
+
+func TestL337CharFormatter_weights(t *testing.T) {
+	formatter := NewL337CharFormatter(Alphabet{'A': {{Text: "4", Weight: 1}, {Text: "@", Weight: 3}}}, NewSeededRand([]byte("weights")))
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		counts[string(formatter.FormatRune('a'))]++
+	}
+	if counts["4"]+counts["@"] != 4000 || counts["@"] < 2800 || counts["@"] > 3200 {
+		t.Errorf("expected the alternatives to be chosen by weight for each rune, got %v", counts)
+	}
+	counter := NewEntropyCounter(NewSeededRand([]byte("weights")))
+	NewL337CharFormatter(l337Alphabet, counter).FormatRune('a')
+	if counter.Bits() != 0 {
+		t.Errorf("expected a single alternative to be chosen without a random decision")
+	}
+	NewL337CharFormatter(uber1337Alphabet, counter).FormatRune('a')
+	if counter.Bits() != 2 {
+		t.Errorf("expected a choice among four alternatives to be two bits, got %v", counter.Bits())
+	}
+}
@@ -1,15 +1,15 @@
 package profaneword
 
 import (
-	"math/big"
-	"strings"
 	"unicode"
 )
 
-// NewRandomFormatter is a Returns a RandomlyFormattingCharFormatter that delegates to another CharFormatter at a rate of 50%
-func NewRandomFormatter() *RandomlyFormattingCharFormatter {
+// NewRandomFormatter is a Returns a RandomlyFormattingCharFormatter that delegates to another CharFormatter at a rate of 50%,
+// or at the rate given by WithThreshold
+func NewRandomFormatter(opts ...Option) *RandomlyFormattingCharFormatter {
+	o := newOptions(opts)
 	return &RandomlyFormattingCharFormatter{
-		thresholdRandom: newFiftyFifty(),
+		thresholdRandom: o.thresholdRandom(),
 	}
 }
 
@@ -31,132 +31,69 @@ var _ WrappingCharFormatter = &RandomlyFormattingCharFormatter{}
 
 // FormatRune formats a single rune or not given the Random with threshold
 func (rff *RandomlyFormattingCharFormatter) FormatRune(r rune) []rune {
-	if rff.Rand.Rand().Cmp(rff.Threshold) > 0 {
+	if randAbove(rff.Rand, rff.threshold()) {
 		return rff.Other.FormatRune(r)
 	}
 	return []rune{r}
 }
 
-// small subset of 1337 alphabet
-var l337Map = map[rune][]rune{
-	'A': {'4'},
-	'B': {'8'},
-	'E': {'3'},
-	'G': {'6'},
-	'I': {'1'},
-	'L': {'1'},
-	'O': {'0'},
-	'S': {'5'},
-	'T': {'7'},
-	'Z': {'2'},
-}
-
-// curated list values from https://da.wikipedia.org/wiki/Leetspeak
-var uberl337Map = map[rune][][]rune{
-	'A': {{'4'}, {'/', '\\'}, {'@'}, {'/', '-', '\\'}},
-	'B': {{'8'}, {'1', '3'}, {'|', '3'}, {'!', '3'}},
-	'C': {{'['}, {'('}, {'<'}},
-	'D': {{')'}, {'[', ')'}},
-	'E': {{'3'}},
-	'F': {{'|', '='}, {'|', '#'}},
-	'G': {{'6'}, {'(', '_', '+'}},
-	'H': {{'#'}, {']', '-', '['}, {'|', '-', '|'}},
-	'I': {{'1'}, {'!'}, {'|'}},
-	'J': {{'_', '|'}},
-	'K': {{'|', '<'}},
-	'L': {{'1'}, {'|', '_'}, {'|'}},
-	'M': {{'|', 'v', '|'}, {'|', '\\', '/', '|'}},
-	'N': {{'|', '\\', '|'}, {'|', 'V'}},
-	'O': {{'0'}, {'(', ')'}},
-	'P': {{'|', '>'}},
-	'Q': {{'(', ')', '_'}},
-	'R': {{'2'}, {'1', '2'}, {'|', '?'}},
-	'S': {{'5'}, {'$'}, {'§'}, {'z'}, {'Z'}},
-	'T': {{'7'}, {'+'}},
-	'U': {{'(', '_', ')'}, {'|', '_', '|'}},
-	'V': {{'\\', '/'}},
-	'W': {{'\\', '/', '\\', '/'}, {'v', 'v'}, {'\'', '/', '/'}, {'\\', '\\', '\''}},
-	'X': {{'>', '<'}, {'}', '{'}},
-	'Y': {{'`', '/'}},
-	'Z': {{'2'}, {'~', '/', '_'}},
-}
-
 // L337CharFormatter is a CharFormatter that formats by replacing
-// the given rune by a slice if runes as stated in the internal map
+// the given rune by one of its alternatives in the Alphabet, chosen anew for each rune
 type L337CharFormatter struct {
-	uber1337 map[rune][]rune
+	alphabet Alphabet
+	rand     RandomDevice
 }
 
 var _ CharFormatter = L337CharFormatter{}
 
-// FormatRune returns the rune-slice given in the internal map, or returns the input value
-func (u L337CharFormatter) FormatRune(r rune) []rune {
-	rKey := unicode.ToUpper(r)
-	if leetVal, ok := u.uber1337[rKey]; ok {
-		return leetVal
+// NewL337CharFormatter returns a L337CharFormatter of the Alphabet, that chooses among alternatives using the RandomDevice,
+// or CryptoRand if it is nil
+func NewL337CharFormatter(alphabet Alphabet, device RandomDevice) L337CharFormatter {
+	if device == nil {
+		device = CryptoRand{}
 	}
-	return []rune{r}
+	return L337CharFormatter{alphabet: alphabet, rand: device}
 }
 
-// Uber1337Formatter returns an initiated randomly chosen L337CharFormatter with the special uber1337-map
-func Uber1337Formatter() Formatter {
-	uber133Map := buildRandomMap(uberl337Map)
-	return &CharFormatterDelegatingFormatter{
-		&L337CharFormatter{
-			uber133Map,
-		},
+// FormatRune returns one of the alternatives of the rune in the Alphabet, or returns the input value
+func (u L337CharFormatter) FormatRune(r rune) []rune {
+	if alternatives, ok := u.alphabet[unicode.ToUpper(r)]; ok && len(alternatives) > 0 {
+		return []rune(choose(u.rand, alternatives).Text)
 	}
+	return []rune{r}
 }
 
-func buildRandomMap(m map[rune][][]rune) map[rune][]rune {
-	randDev := CryptoRand{}
-	var randomMap = make(map[rune][]rune, len(m))
-	for k, v := range m {
-		idx := randDev.RandMax(len(v))
-		randomMap[k] = v[idx]
-	}
-	return randomMap
+// Alphabet returns the Alphabet of the L337CharFormatter
+func (u L337CharFormatter) Alphabet() Alphabet {
+	return u.alphabet
 }
 
-// L337Formatter returns a L337CharFormatter with a predefined mapping, the l337map
-func L337Formatter() Formatter {
-	return &CharFormatterDelegatingFormatter{
-		&L337CharFormatter{
-			l337Map,
-		},
+// Uber1337Formatter returns a L337CharFormatter of the extended uber1337 alphabet, or the alphabet given by WithL337Alphabet,
+// choosing randomly among the alternatives of each letter
+func Uber1337Formatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	alphabet := o.alphabet
+	if alphabet == nil {
+		alphabet = uber1337Alphabet
 	}
+	return &CharFormatterDelegatingFormatter{NewL337CharFormatter(alphabet, o.rand)}
 }
 
-var keyboard = [5]string{
-	"1234567890-",
-	"qwertyuiop[",
-	"asdfghjkl;",
-	"zxcvbnm,.",
-	"       ",
-}
-
-func getNeighbourgChars(r rune) string {
-	for j, line := range keyboard[1:3] {
-		jIdx := j + 1
-		idx := strings.Index(line, string(r))
-		if idx != -1 {
-			idxlower := idx - 1
-			if idxlower == -1 {
-				idxlower = idx
-			}
-			var lower string
-			if r != 'z' && r != 'x' {
-				lower = keyboard[jIdx+1][idxlower:idx]
-			}
-			return keyboard[jIdx-1][idx:idx+1] + keyboard[jIdx][idxlower:idx+1] + lower
-		}
+// L337Formatter returns a L337CharFormatter with a predefined mapping, or the alphabet given by WithL337Alphabet
+func L337Formatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	alphabet := o.alphabet
+	if alphabet == nil {
+		alphabet = l337Alphabet
 	}
-	return string(r)
+	return &CharFormatterDelegatingFormatter{NewL337CharFormatter(alphabet, o.rand)}
 }
 
-// FatFingerCharFormatter formats the text/rune as if it was types with fat fingers
+// FatFingerCharFormatter formats the text/rune as if it was types with fat fingers,
+// on the KeyboardLayout, or QWERTY if it is nil
 type FatFingerCharFormatter struct {
 	RandomDevice
+	Layout *KeyboardLayout
 }
 
 var _ CharFormatter = FatFingerCharFormatter{}
@@ -164,20 +101,27 @@ var _ CharFormatter = FatFingerCharFormatter{}
 // FormatRune returns the slice of runes by finding the neighboring characters (keyboard) and
 // returns a random set of characters from within that sequence. it may return the rune itself up to four times
 func (ff FatFingerCharFormatter) FormatRune(r rune) []rune {
-	if ff.Rand().Cmp(big.NewRat(1, 6)) < 0 {
+	if randBelow(ff.RandomDevice, 1./6) {
 		var outRunes []rune
+		layout := ff.Layout
+		if layout == nil {
+			layout = KeyboardLayouts[0]
+		}
+		newChars := layout.Neighbours(r)
+		if len(newChars) == 0 {
+			newChars = []rune{r}
+		}
 		for len(outRunes) == 0 {
-			if ff.Rand().Cmp(big.NewRat(1, 6)) < 0 {
+			if randBelow(ff.RandomDevice, 1./6) {
 				outRunes = append(outRunes, r)
 			}
-			newChars := getNeighbourgChars(r)
-			if ff.Rand().Cmp(big.NewRat(2, 5)) < 0 {
-				outRunes = append(outRunes, rune(newChars[ff.RandMax(len(newChars))]))
+			if randBelow(ff.RandomDevice, 2./5) {
+				outRunes = append(outRunes, newChars[ff.RandMax(len(newChars))])
 			}
-			if ff.Rand().Cmp(big.NewRat(1, 12)) < 0 {
-				outRunes = append(outRunes, rune(newChars[ff.RandMax(len(newChars))]))
+			if randBelow(ff.RandomDevice, 1./12) {
+				outRunes = append(outRunes, newChars[ff.RandMax(len(newChars))])
 			}
-			if ff.Rand().Cmp(big.NewRat(1, 7)) < 0 {
+			if randBelow(ff.RandomDevice, 1./7) {
 				outRunes = append(outRunes, r)
 			}
 		}
@@ -187,8 +131,9 @@ func (ff FatFingerCharFormatter) FormatRune(r rune) []rune {
 }
 
 // NewFatFingerFormatter wraps the FatFingerCharFormatter in a CharFormatterDelegatingFormatter to produce a Formatter
-func NewFatFingerFormatter() Formatter {
-	return &CharFormatterDelegatingFormatter{CharFormatter: FatFingerCharFormatter{CryptoRand{}}}
+func NewFatFingerFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &CharFormatterDelegatingFormatter{CharFormatter: FatFingerCharFormatter{o.rand, o.layout}}
 }
 
 // FastFingerCharFormatter formats as if written with haste, skipping characters at random
@@ -200,15 +145,16 @@ var _ CharFormatter = FastFingerCharFormatter{}
 
 // FormatRune at a rate of 1/6 randomly skip a rune
 func (ff FastFingerCharFormatter) FormatRune(r rune) []rune {
-	if ff.Rand().Cmp(big.NewRat(1, 6)) < 0 {
+	if randBelow(ff.RandomDevice, 1./6) {
 		return []rune{}
 	}
 	return []rune{r}
 }
 
 // NewFastFingerFormatter returns an initiated FastFingerCharFormatter wrapped in a CharFormatterDelegatingFormatter to produce a Formatter
-func NewFastFingerFormatter() Formatter {
-	return &CharFormatterDelegatingFormatter{CharFormatter: FastFingerCharFormatter{CryptoRand{}}}
+func NewFastFingerFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &CharFormatterDelegatingFormatter{CharFormatter: FastFingerCharFormatter{o.rand}}
 }
 
 // UppercaseCharFormatter formats uppercase
@@ -221,7 +167,8 @@ func (UppercaseCharFormatter) FormatRune(r rune) []rune {
 	return []rune{unicode.ToUpper(r)}
 }
 
-func NewUppercaseFormatter() Formatter {
+// NewUppercaseFormatter returns an UppercaseCharFormatter wrapped in a CharFormatterDelegatingFormatter
+func NewUppercaseFormatter(...Option) Formatter {
 	return &CharFormatterDelegatingFormatter{CharFormatter: UppercaseCharFormatter{}}
 }
 
@@ -235,7 +182,8 @@ func (LowercaseCharFormatter) FormatRune(r rune) []rune {
 	return []rune{unicode.ToLower(r)}
 }
 
-func NewLowercaseFormatter() Formatter {
+// NewLowercaseFormatter returns a LowercaseCharFormatter wrapped in a CharFormatterDelegatingFormatter
+func NewLowercaseFormatter(...Option) Formatter {
 	return &CharFormatterDelegatingFormatter{CharFormatter: LowercaseCharFormatter{}}
 }
 
@@ -254,8 +202,8 @@ func (SwitchCaseCharFormatter) FormatRune(r rune) []rune {
 
 // NewSarcasticFormatter returns a CharFormatterDelegatingFormatter that wraps a
 // RandomlyFormattingCharFormatter that randomly delegates to SwitchCaseCharFormatter
-func NewSarcasticFormatter() Formatter {
-	randomFormatter := NewRandomFormatter()
+func NewSarcasticFormatter(opts ...Option) Formatter {
+	randomFormatter := NewRandomFormatter(opts...)
 	randomFormatter.Other = &SwitchCaseCharFormatter{}
 	return &CharFormatterDelegatingFormatter{randomFormatter}
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func TestL337CharFormatter_FormatRune(t *testing.T) {
-	abFormatter := L337CharFormatter{map[rune][]rune{'a': {'b'}}}
+	abFormatter := NewL337CharFormatter(Alphabet{'A': uniform("b")}, nil)
 	got := abFormatter.FormatRune('a')
 	if len(got) != 1 && got[0] != 'b' {
 		t.Errorf("L337CharFormatter misformatted, expected: {'b'} got: %v", got)
@@ -42,7 +42,7 @@ func TestFastFingerCharFormatter_FormatRune(t *testing.T) {
 }
 
 func TestFatFingerCharFormatter_FormatRune(t *testing.T) {
-	fatf := FatFingerCharFormatter{zeroRandomDevice(0)}
+	fatf := FatFingerCharFormatter{RandomDevice: zeroRandomDevice(0)}
 	if got := fatf.FormatRune('a'); len(got) != 1 && got[0] != 'a' {
 		t.Errorf("FatFingerCharFormatter did return unit-slice as expected, got: %v", got)
 	}
@@ -0,0 +1,222 @@
+// Code generated by gen_confusables.go of confusables.txt, Version: 15.1.0; DO NOT EDIT.
+
+package profaneword
+
+// confusablesVersion is the version of the Unicode confusables data of confusables
+const confusablesVersion = "15.1.0"
+
+// confusables are the homoglyphs of the Latin letters of the Unicode confusables data
+var confusables = map[rune][]string{
+	'A': {
+		"\u0391", // Α
+		"\u0410", // А
+		"\u13aa", // Ꭺ
+	},
+	'B': {
+		"\u0392", // Β
+		"\u0412", // В
+		"\u13f4", // Ᏼ
+	},
+	'C': {
+		"\u03f9", // Ϲ
+		"\u0421", // С
+		"\u216d", // Ⅽ
+	},
+	'D': {
+		"\u13a0", // Ꭰ
+		"\u216e", // Ⅾ
+	},
+	'E': {
+		"\u0395", // Ε
+		"\u0415", // Е
+		"\u13ac", // Ꭼ
+	},
+	'F': {
+		"\u03dc", // Ϝ
+	},
+	'G': {
+		"\u050c", // Ԍ
+		"\u13c0", // Ꮐ
+	},
+	'H': {
+		"\u0397", // Η
+		"\u041d", // Н
+		"\u13bb", // Ꮋ
+	},
+	'I': {
+		"\u0399", // Ι
+		"\u0406", // І
+		"\u04c0", // Ӏ
+		"\u2160", // Ⅰ
+	},
+	'J': {
+		"\u0408", // Ј
+		"\u13ab", // Ꭻ
+	},
+	'K': {
+		"\u039a", // Κ
+		"\u041a", // К
+		"\u13e6", // Ꮶ
+		"\u212a", // K
+	},
+	'L': {
+		"\u13de", // Ꮮ
+		"\u216c", // Ⅼ
+	},
+	'M': {
+		"\u039c", // Μ
+		"\u041c", // М
+		"\u13b7", // Ꮇ
+		"\u216f", // Ⅿ
+	},
+	'N': {
+		"\u039d", // Ν
+	},
+	'O': {
+		"\u039f", // Ο
+		"\u041e", // О
+		"\u0555", // Օ
+	},
+	'P': {
+		"\u03a1", // Ρ
+		"\u0420", // Р
+		"\u13e2", // Ꮲ
+	},
+	'Q': {
+		"\u051a", // Ԛ
+	},
+	'R': {
+		"\u13d2", // Ꮢ
+	},
+	'S': {
+		"\u0405", // Ѕ
+		"\u13da", // Ꮪ
+	},
+	'T': {
+		"\u03a4", // Τ
+		"\u0422", // Т
+		"\u13a2", // Ꭲ
+	},
+	'U': {
+		"\u054d", // Ս
+	},
+	'V': {
+		"\u0474", // Ѵ
+		"\u13d9", // Ꮩ
+		"\u2164", // Ⅴ
+	},
+	'W': {
+		"\u051c", // Ԝ
+		"\u13b3", // Ꮃ
+	},
+	'X': {
+		"\u03a7", // Χ
+		"\u0425", // Х
+		"\u2169", // Ⅹ
+	},
+	'Y': {
+		"\u03a5", // Υ
+		"\u04ae", // Ү
+	},
+	'Z': {
+		"\u0396", // Ζ
+		"\u13c3", // Ꮓ
+	},
+	'a': {
+		"\u0251", // ɑ
+		"\u0430", // а
+	},
+	'b': {
+		"\u15af", // ᖯ
+	},
+	'c': {
+		"\u03f2", // ϲ
+		"\u0441", // с
+		"\u1d04", // ᴄ
+	},
+	'd': {
+		"cl",
+		"\u0501", // ԁ
+		"\u217e", // ⅾ
+	},
+	'e': {
+		"\u0435", // е
+		"\u04bd", // ҽ
+	},
+	'g': {
+		"\u0261", // ɡ
+		"\u0581", // ց
+	},
+	'h': {
+		"\u04bb", // һ
+		"\u0570", // հ
+	},
+	'i': {
+		"\u0269", // ɩ
+		"\u0456", // і
+		"\u2170", // ⅰ
+	},
+	'j': {
+		"\u03f3", // ϳ
+		"\u0458", // ј
+	},
+	'l': {
+		"\u01c0", // ǀ
+		"\u04cf", // ӏ
+		"\u217c", // ⅼ
+	},
+	'm': {
+		"rn",
+		"\u217f", // ⅿ
+	},
+	'n': {
+		"\u0578", // ո
+	},
+	'o': {
+		"\u03bf", // ο
+		"\u043e", // о
+		"\u0585", // օ
+		"\u1d0f", // ᴏ
+	},
+	'p': {
+		"\u03c1", // ρ
+		"\u0440", // р
+	},
+	'q': {
+		"\u051b", // ԛ
+		"\u0566", // զ
+	},
+	'r': {
+		"\u0433", // г
+	},
+	's': {
+		"\u0455", // ѕ
+		"\ua731", // ꜱ
+	},
+	'u': {
+		"\u03c5", // υ
+		"\u057d", // ս
+		"\u1d1c", // ᴜ
+	},
+	'v': {
+		"\u03bd", // ν
+		"\u0475", // ѵ
+		"\u1d20", // ᴠ
+		"\u2174", // ⅴ
+	},
+	'w': {
+		"\u051d", // ԝ
+		"\u1d21", // ᴡ
+	},
+	'x': {
+		"\u0445", // х
+		"\u2179", // ⅹ
+	},
+	'y': {
+		"\u0443", // у
+		"\u04af", // ү
+	},
+	'z': {
+		"\u1d22", // ᴢ
+	},
+}
@@ -0,0 +1,234 @@
+package profaneword
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Describer is a Formatter or CharFormatter that describes what it does, without the formatters it wraps
+type Describer interface {
+	Describe() string
+}
+
+// formatterGetter is a Formatter that wraps another Formatter
+type formatterGetter interface {
+	GetFormatter() Formatter
+}
+
+// charFormatterGetter is a Formatter or CharFormatter that wraps a CharFormatter
+type charFormatterGetter interface {
+	GetCharFormatter() CharFormatter
+}
+
+// Children returns the Formatters and CharFormatters that the Formatter, or CharFormatter, f wraps
+func Children(f interface{}) []interface{} {
+	var children []interface{}
+	switch wrapping := f.(type) {
+	case *MultiFormatter:
+		for _, child := range wrapping.Formatters {
+			children = append(children, child)
+		}
+	case formatterGetter:
+		if child := wrapping.GetFormatter(); child != nil {
+			children = append(children, child)
+		}
+	case charFormatterGetter:
+		if child := wrapping.GetCharFormatter(); child != nil {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Walk calls fn for the Formatter, or CharFormatter, f and everything it wraps, depth first, with the depth in the tree
+func Walk(f interface{}, fn func(f interface{}, depth int)) {
+	walk(f, 0, fn)
+}
+
+func walk(f interface{}, depth int, fn func(f interface{}, depth int)) {
+	fn(f, depth)
+	for _, child := range Children(f) {
+		walk(child, depth+1, fn)
+	}
+}
+
+// DescriptionOf returns the description of a single Formatter or CharFormatter,
+// the type is used for formatters that are not a Describer
+func DescriptionOf(f interface{}) string {
+	if d, ok := f.(Describer); ok {
+		return d.Describe()
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", f), "*")
+}
+
+// Describe returns the tree of the Formatter, or CharFormatter, f, a line for each formatter indented by its depth
+func Describe(f interface{}) string {
+	sb := strings.Builder{}
+	Walk(f, func(f interface{}, depth int) {
+		sb.WriteString(strings.Repeat("  ", depth))
+		sb.WriteString(DescriptionOf(f))
+		sb.WriteString("\n")
+	})
+	return sb.String()
+}
+
+// probabilityOf returns the probability of applying a formatter at the threshold, as short text
+func probabilityOf(threshold float64) string {
+	return strconv.FormatFloat(1-threshold, 'g', 4, 64)
+}
+
+// tokenizerName returns the name of the Tokenizer, as given to the tokenizer flag of the profaneword command
+func tokenizerName(t Tokenizer) string {
+	switch tokenizer := t.(type) {
+	case nil, WhitespaceTokenizer:
+		return "whitespace"
+	case WordTokenizer:
+		return "words"
+	case RegexpTokenizer:
+		return "regexp:" + tokenizer.String()
+	}
+	return fmt.Sprintf("%T", t)
+}
+
+// Describe is "in order", the wrapped formatters are applied in order
+func (m *MultiFormatter) Describe() string {
+	return "in order"
+}
+
+// Describe is "unchanged"
+func (UnitFormatter) Describe() string {
+	return "unchanged"
+}
+
+// Describe is "per character"
+func (c *CharFormatterDelegatingFormatter) Describe() string {
+	return "per character"
+}
+
+// Describe is "per word", and the tokenizer
+func (p *PerWordFormattingFormatter) Describe() string {
+	return "per word, split by " + tokenizerName(p.Tokenizer)
+}
+
+// Describe is "randomly", and the probability of formatting a text
+func (rff *RandomlyFormattingFormatter) Describe() string {
+	return "randomly, with probability " + probabilityOf(rff.threshold())
+}
+
+// Describe is "randomly", and the probability of formatting a character
+func (rff *RandomlyFormattingCharFormatter) Describe() string {
+	return "randomly, with probability " + probabilityOf(rff.threshold())
+}
+
+// Describe is "title case"
+func (TitleFormatter) Describe() string {
+	return "title case"
+}
+
+// Describe is the pattern and replacement
+func (rr *RegexReplacingFormatter) Describe() string {
+	return fmt.Sprintf("replace /%s/ with %q", rr.PatternMatcher, rr.Replacement)
+}
+
+// Describe is "reverse"
+func (ReversingFormatter) Describe() string {
+	return "reverse"
+}
+
+// Describe is "swear"
+func (s *swearFormatter) Describe() string {
+	return "swear, replacing the leading letters and adding a !"
+}
+
+// Describe is "studder"
+func (StudderFormatter) Describe() string {
+	return "studder"
+}
+
+// Describe is "horse"
+func (HorseFormatter) Describe() string {
+	return "horse words"
+}
+
+// Describe is "shuffle"
+func (ShuffleFormatter) Describe() string {
+	return "shuffle"
+}
+
+// Describe is "1337", and the number of letters replaced
+func (u L337CharFormatter) Describe() string {
+	return fmt.Sprintf("1337, replacing %d letters", len(u.alphabet))
+}
+
+// Describe is "homoglyphs", and the Strictness
+func (h HomoglyphCharFormatter) Describe() string {
+	return "homoglyphs of other scripts, at strictness " + h.strictness.String()
+}
+
+// Describe is "zalgo", and the probability of each mark
+func (z ZalgoCharFormatter) Describe() string {
+	return "zalgo, stacking combining marks with probability " + probabilityOf(z.threshold())
+}
+
+// Describe is "NATO phonetic alphabet"
+func (NATOFormatter) Describe() string {
+	return "NATO phonetic alphabet, spelling out case and symbols"
+}
+
+// Describe is "Morse code"
+func (MorseFormatter) Describe() string {
+	return "International Morse code"
+}
+
+// Describe is "Braille"
+func (BrailleFormatter) Describe() string {
+	return "uncontracted English Braille"
+}
+
+// Describe is "Pig Latin"
+func (PigLatinFormatter) Describe() string {
+	return "Pig Latin"
+}
+
+// Describe is "emoji", the mode, and the number of keywords
+func (e EmojiFormatter) Describe() string {
+	if e.Mode == ReplaceWithEmoji {
+		return fmt.Sprintf("emoji, replacing the words of %d keywords", len(e.Table))
+	}
+	return fmt.Sprintf("emoji, appended to the words of %d keywords", len(e.Table))
+}
+
+// Describe is "fat fingers", and the keyboard layout
+func (ff FatFingerCharFormatter) Describe() string {
+	layout := ff.Layout
+	if layout == nil {
+		layout = KeyboardLayouts[0]
+	}
+	return "fat fingers, typing a neighbouring key on " + layout.Description + " with probability 1/6"
+}
+
+// Describe is "fast fingers"
+func (FastFingerCharFormatter) Describe() string {
+	return "fast fingers, skipping a character with probability 1/6"
+}
+
+// Describe is "uppercase"
+func (UppercaseCharFormatter) Describe() string {
+	return "uppercase"
+}
+
+// Describe is "lowercase"
+func (LowercaseCharFormatter) Describe() string {
+	return "lowercase"
+}
+
+// Describe is "switch case"
+func (SwitchCaseCharFormatter) Describe() string {
+	return "switch case"
+}
+
+// Describe is "a random symbol"
+func (swearCharFormatter) Describe() string {
+	return `a random symbol of #&$@%+*"`
+}
@@ -0,0 +1,48 @@
+package profaneword
+
+import (
+	"math/big"
+	"testing"
+)
+
+type opaqueFormatter struct{}
+
+func (opaqueFormatter) Format(text string) string {
+	return text
+}
+
+func TestDescribe(t *testing.T) {
+	mf := &MultiFormatter{}
+	mf.With(NewRandomlyFormatter(NewUppercaseFormatter(), WithThreshold(big.NewRat(7, 10))))
+	mf.With(NewPerWordFormatter(opaqueFormatter{}, WithTokenizer(WordTokenizer{})))
+	mf.With(DelimiterFormatterWith("-"))
+	expected := `in order
+  per word, split by whitespace
+    randomly, with probability 0.3
+      per character
+        uppercase
+  per word, split by words
+    profaneword.opaqueFormatter
+  replace / / with "-"
+`
+	if got := Describe(mf); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	sarcastic := NewSarcasticFormatter(WithRandom(maxRandomDevice{}))
+	var depths []int
+	Walk(sarcastic, func(f interface{}, depth int) {
+		depths = append(depths, depth)
+	})
+	if len(depths) != 3 || depths[2] != 2 {
+		t.Errorf("expected the sarcastic formatter to be three formatters deep, got %v", depths)
+	}
+	if children := Children(UnitFormatter{}); len(children) != 0 {
+		t.Errorf("expected no children, got %v", children)
+	}
+	if children := Children(&RandomlyFormattingFormatter{}); len(children) != 0 {
+		t.Errorf("expected an unset wrapped formatter to be skipped, got %v", children)
+	}
+}
@@ -0,0 +1,180 @@
+package profaneword
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EmojiTable is a table of keywords, in lowercase, to their emoji
+type EmojiTable map[string]string
+
+// emojiKeywords is the built-in EmojiTable
+var emojiKeywords = EmojiTable{
+	"angry": "😠", "ass": "🍑", "baby": "👶", "balls": "⚽", "banana": "🍌", "beer": "🍺", "bitch": "🐕", "bomb": "💣",
+	"boob": "🍈", "brain": "🧠", "butt": "🍑", "cat": "🐈", "chicken": "🐔", "clown": "🤡", "cock": "🐓", "cow": "🐄",
+	"crap": "💩", "crazy": "🤪", "cry": "😭", "damn": "😤", "dead": "💀", "death": "💀", "devil": "😈", "dick": "🍆",
+	"dog": "🐕", "donkey": "🫏", "duck": "🦆", "dumb": "🥴", "eggplant": "🍆", "evil": "😈", "fart": "💨", "fat": "🐷",
+	"fire": "🔥", "fish": "🐟", "fuck": "🖕", "ghost": "👻", "goat": "🐐", "god": "🙏", "hate": "💢", "heart": "💖",
+	"hell": "🔥", "horse": "🐎", "idiot": "🤡", "kiss": "💋", "kitten": "🐈", "love": "💖", "mad": "😡", "money": "💰",
+	"monkey": "🐒", "moron": "🤡", "nerd": "🤓", "nuts": "🥜", "peach": "🍑", "pig": "🐖", "pizza": "🍕", "poop": "💩",
+	"puke": "🤮", "pussy": "🐈", "rat": "🐀", "sex": "🍆", "shit": "💩", "sick": "🤢", "skull": "💀", "snake": "🐍",
+	"stupid": "🤪", "sweet": "🍬", "toilet": "🚽", "turd": "💩", "ugly": "👹", "vomit": "🤮", "weird": "🤪", "wtf": "🤯",
+}
+
+// DefaultEmojiTable returns a copy of the built-in EmojiTable
+func DefaultEmojiTable() EmojiTable {
+	return emojiKeywords.With(nil)
+}
+
+// With returns a copy of the EmojiTable extended by the other, the emoji of the other are used for keywords in both
+func (t EmojiTable) With(other EmojiTable) EmojiTable {
+	table := make(EmojiTable, len(t)+len(other))
+	for keyword, emoji := range t {
+		table[keyword] = emoji
+	}
+	for keyword, emoji := range other {
+		table[keyword] = emoji
+	}
+	return table
+}
+
+// emojiInflections are the endings of the possessive, plural and -ing forms, that are removed from a word to find its keyword,
+// fx horses is horse and fucking is fuck. The keyword must have at least minLength letters, such that rats is rat, but rating is not.
+// Other endings are not removed, as too many words end like an inflected keyword, fx rated and cower
+var emojiInflections = []struct {
+	suffix    string
+	minLength int
+}{{"'s", 3}, {"s'", 3}, {"s", 3}, {"es", 4}, {"ing", 4}, {"in'", 4}}
+
+// Lookup returns the emoji of the word: of the word in lowercase, the first part of a hyphenated word that has an emoji,
+// or the possessive, plural or -ing form of a keyword, fx horses is horse and puking is puke
+func (t EmojiTable) Lookup(word string) (string, bool) {
+	word = strings.ToLower(word)
+	if emoji, ok := t[word]; ok {
+		return emoji, true
+	}
+	if parts := strings.Split(word, "-"); len(parts) > 1 {
+		for _, part := range parts {
+			if emoji, ok := t.Lookup(part); ok {
+				return emoji, true
+			}
+		}
+		return "", false
+	}
+	for _, inflection := range emojiInflections {
+		stem := strings.TrimSuffix(word, inflection.suffix)
+		if stem == word {
+			continue
+		}
+		keywords := []string{stem}
+		if strings.HasPrefix(inflection.suffix, "in") {
+			keywords = append(keywords, stem+"e")
+		}
+		for _, keyword := range keywords {
+			if emoji, ok := t[keyword]; ok && utf8.RuneCountInString(keyword) >= inflection.minLength {
+				return emoji, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ParseEmojiTableJSON parses a JSON object of keywords to emoji, fx {"horse": "🐎"}, the keywords are made lowercase
+func ParseEmojiTableJSON(data []byte) (EmojiTable, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return emojiTableOf(doc)
+}
+
+// emojiTableOf returns the EmojiTable of a decoded document
+func emojiTableOf(doc map[string]interface{}) (EmojiTable, error) {
+	keywords := make([]string, 0, len(doc))
+	for keyword := range doc {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	table := make(EmojiTable, len(doc))
+	for _, keyword := range keywords {
+		emoji, ok := doc[keyword].(string)
+		if !ok || emoji == "" {
+			return nil, fmt.Errorf("%q: expected an emoji, got %v", keyword, doc[keyword])
+		}
+		lower := strings.ToLower(keyword)
+		if lower == "" || strings.IndexFunc(lower, unicode.IsSpace) != -1 {
+			return nil, fmt.Errorf("%q is not a single word", keyword)
+		}
+		if _, dup := table[lower]; dup {
+			return nil, fmt.Errorf("the keyword %q is given twice", keyword)
+		}
+		table[lower] = emoji
+	}
+	return table, nil
+}
+
+// EmojiMode is how an EmojiFormatter adds the emoji of a word
+type EmojiMode uint8
+
+const (
+	// AppendEmoji adds the emoji after the word
+	AppendEmoji EmojiMode = iota
+	// ReplaceWithEmoji replaces the word by the emoji
+	ReplaceWithEmoji
+)
+
+// EmojiFormatter is a Formatter that adds the emoji of the words it recognises in the Table, per the Mode.
+// Characters before and after the letters of the word, like punctuation, are kept in place
+type EmojiFormatter struct {
+	Table EmojiTable
+	Mode  EmojiMode
+}
+
+var _ Formatter = EmojiFormatter{}
+
+// Format returns the word with its emoji, or the word if it has none
+func (e EmojiFormatter) Format(word string) string {
+	word = validUTF8(word)
+	start, end := letterSpan(word)
+	if start == end {
+		return word
+	}
+	emoji, ok := e.Table.Lookup(word[start:end])
+	if !ok {
+		return word
+	}
+	if e.Mode == ReplaceWithEmoji {
+		return word[:start] + emoji + word[end:]
+	}
+	return word[:end] + emoji + word[end:]
+}
+
+// NewEmojiFormatter returns an EmojiFormatter per word, that appends the emoji of the EmojiTable given by WithEmojiTable,
+// or the built-in table; words are split by the Tokenizer given by WithTokenizer
+func NewEmojiFormatter(opts ...Option) Formatter {
+	return newEmojiFormatter(AppendEmoji, opts)
+}
+
+func newEmojiFormatter(mode EmojiMode, opts []Option) Formatter {
+	o := newOptions(opts)
+	table := emojiKeywords
+	if o.emojis != nil {
+		table = o.emojis()
+	}
+	return NewPerWordFormatter(EmojiFormatter{Table: table, Mode: mode}, opts...)
+}
+
+// emojiFactory is the Factory of the emoji Formatter, the parameter is the mode: append or replace
+func emojiFactory(param string, opts ...Option) (Formatter, error) {
+	switch param {
+	case "", "append":
+		return newEmojiFormatter(AppendEmoji, opts), nil
+	case "replace":
+		return newEmojiFormatter(ReplaceWithEmoji, opts), nil
+	}
+	return nil, fmt.Errorf("unknown emoji mode %q, expected append or replace", param)
+}
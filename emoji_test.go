@@ -0,0 +1,114 @@
+package profaneword
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEmojiTable_Lookup(t *testing.T) {
+	words := map[string]string{
+		"horse":       "🐎",
+		"HORSE":       "🐎",
+		"horses":      "🐎",
+		"rats":        "🐀",
+		"devil's":     "😈",
+		"kisses":      "💋",
+		"bitches":     "🐕",
+		"fucking":     "🖕",
+		"puking":      "🤮",
+		"fuckin'":     "🖕",
+		"horse-faced": "🐎",
+		"rated":       "",
+		"rating":      "",
+		"cower":       "",
+		"caters":      "",
+		"horsey":      "",
+		"asses":       "", // -es of a keyword of three letters
+		"ratchet":     "",
+		"-":           "",
+	}
+	for word, expected := range words {
+		emoji, ok := emojiKeywords.Lookup(word)
+		if emoji != expected || ok != (expected != "") {
+			t.Errorf("expected the emoji of %q to be %q, got %q", word, expected, emoji)
+		}
+	}
+	if emoji, _ := (EmojiTable{"caters": "🍽"}).Lookup("caters"); emoji != "🍽" {
+		t.Errorf("expected a keyword of the table to be used as it is, got %q", emoji)
+	}
+}
+
+func TestEmojiFormatter(t *testing.T) {
+	table := EmojiTable{"horse": "🐎", "poop": "💩"}
+	words := map[string][2]string{ // the word appended and replaced
+		"horses,": {"horses🐎,", "🐎,"},
+		"(poop)!": {"(poop💩)!", "(💩)!"},
+		"42":      {"42", "42"},
+		"...":     {"...", "..."},
+		"rock":    {"rock", "rock"},
+	}
+	for word, expected := range words {
+		for mode, e := range []EmojiFormatter{{Table: table, Mode: AppendEmoji}, {Table: table, Mode: ReplaceWithEmoji}} {
+			if got := e.Format(word); got != expected[mode] {
+				t.Errorf("%s: expected %q to be %q, got %q", DescriptionOf(e), word, expected[mode], got)
+			}
+		}
+	}
+	if got := NewEmojiFormatter().Format("the devil rides a horse"); got != "the devil😈 rides a horse🐎" {
+		t.Errorf("expected the built-in table per word, got %q", got)
+	}
+	if got := NewEmojiFormatter(WithEmojiTable(EmojiTable{"rock": "🪨"})).Format("horse rock"); got != "horse rock🪨" {
+		t.Errorf("expected the table of the option in stead of the built-in table, got %q", got)
+	}
+	if _, err := emojiFactory("prepend"); err == nil {
+		t.Error("expected an error of an unknown mode")
+	}
+}
+
+func TestWithLazyEmojiTable(t *testing.T) {
+	builds := 0
+	opt := WithLazyEmojiTable(func() EmojiTable {
+		builds++
+		return EmojiTable{"rock": "🪨"}
+	})
+	Uber1337Formatter(opt)
+	if builds != 0 {
+		t.Fatalf("expected the table not to be built before an emoji formatter is made")
+	}
+	first, second := NewEmojiFormatter(opt), NewEmojiFormatter(opt)
+	if builds != 1 {
+		t.Errorf("expected the table to be built once, got %d", builds)
+	}
+	if got := first.Format("rock") + second.Format(" rock"); got != "rock🪨 rock🪨" {
+		t.Errorf("expected the built table to be used, got %q", got)
+	}
+}
+
+func TestEmojiFormatter_Invert(t *testing.T) {
+	text := "Horses, poop! the devil's kitten🐈 rock"
+	inverted, _, err := Invert(NewEmojiFormatter(), NewEmojiFormatter().Format(text))
+	if err != nil || inverted != text {
+		t.Errorf("expected %q, got %q (%v)", text, inverted, err)
+	}
+	replace, _ := emojiFactory("replace")
+	if _, _, err := Invert(replace, "💩"); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("expected replacing emoji not to be invertible, got %v", err)
+	}
+}
+
+func TestParseEmojiTable(t *testing.T) {
+	expected := EmojiTable{"horse": "🦄", "gitlab": "🦊"}
+	fromJSON, err := ParseEmojiTableJSON([]byte(`{"Horse": "🦄", "gitlab": "🦊"}`))
+	if err != nil || !reflect.DeepEqual(fromJSON, expected) {
+		t.Errorf("expected %v, got %v (%v)", expected, fromJSON, err)
+	}
+	for _, invalid := range []string{`{"horse": 1}`, `{"horse": ""}`, `{"Horse": "🦄", "horse": "🐎"}`, `{"dark horse": "🐎"}`} {
+		if _, err := ParseEmojiTableJSON([]byte(invalid)); err == nil {
+			t.Errorf("expected an error of %s", invalid)
+		}
+	}
+	if table := DefaultEmojiTable().With(fromJSON); table["horse"] != "🦄" || emojiKeywords["horse"] != "🐎" {
+		t.Errorf("expected the table to extend a copy of the built-in table, got %q", table["horse"])
+	}
+}
@@ -0,0 +1,233 @@
+package profaneword
+
+import "math"
+
+// Decisions are kinds of random decisions on a text
+type Decisions uint8
+
+const (
+	// CaseDecisions decide the case of the letters, fx title case or sarcastic
+	CaseDecisions Decisions = 1 << iota
+	// TextDecisions decide the characters of the text, fx the words, or the replacements of the letters
+	TextDecisions
+	// AllDecisions are decisions of any kind
+	AllDecisions = CaseDecisions | TextDecisions
+)
+
+// Decider is a Formatter or CharFormatter that reports the kinds of decisions made by it,
+// or by a formatter applying it at random. Formatters that are not a Decider decide TextDecisions
+type Decider interface {
+	Decides() Decisions
+}
+
+// Eraser is a Formatter or CharFormatter that erases the kinds of decisions made before it,
+// such that those decisions no longer change the output, fx lowercase erases the case of the letters.
+// A formatter that erases only some of the decisions of a kind, fx 1337 erasing the case of the letters it replaces,
+// erases the kind, such that the count of an EntropyCounter is not too high
+type Eraser interface {
+	Erases() Decisions
+}
+
+// DecisionsOf returns the kinds of decisions made by the Formatter, or CharFormatter, f and everything it wraps
+func DecisionsOf(f interface{}) (decisions Decisions) {
+	Walk(f, func(f interface{}, _ int) {
+		if len(Children(f)) > 0 {
+			return // the decisions of a wrapping formatter are on applying what it wraps
+		}
+		if decider, ok := f.(Decider); ok {
+			decisions |= decider.Decides()
+		} else {
+			decisions |= TextDecisions
+		}
+	})
+	return decisions
+}
+
+// ErasedBy returns the kinds of decisions erased by the Formatter, or CharFormatter, f.
+// A formatter erases what the formatters it wraps erase, unless it applies them at random
+func ErasedBy(f interface{}) (erased Decisions) {
+	switch erasing := f.(type) {
+	case Eraser:
+		return erasing.Erases()
+	case *RandomlyFormattingFormatter, *RandomlyFormattingCharFormatter:
+		return 0
+	}
+	for _, child := range Children(f) {
+		erased |= ErasedBy(child)
+	}
+	return erased
+}
+
+// EntropyCounter is a RandomDevice that keeps count of the bits of entropy
+// consumed by the random decisions made using it.
+// Uniform choices (RandMax) count log2(max) bits, while decisions on a threshold
+// count the binary entropy of that threshold.
+// The bits are counted by the kind of decisions given to Decide, such that
+// the decisions erased by a later formatter can be dropped from the count by Erase
+type EntropyCounter struct {
+	RandomDevice
+	bits     [AllDecisions + 1]float64
+	decision Decisions
+}
+
+var _ RandomDevice = &EntropyCounter{}
+var _ Source = &EntropyCounter{}
+
+// NewEntropyCounter returns an EntropyCounter counting the decisions made with the given RandomDevice
+func NewEntropyCounter(device RandomDevice) *EntropyCounter {
+	if device == nil {
+		device = CryptoRand{}
+	}
+	return &EntropyCounter{RandomDevice: device}
+}
+
+// RandMax delegates to the wrapped RandomDevice, counting the entropy of a uniform choice among max
+func (e *EntropyCounter) RandMax(max int) int {
+	if max > 1 {
+		e.AddBits(math.Log2(float64(max)))
+	}
+	return e.RandomDevice.RandMax(max)
+}
+
+// IntN delegates to the wrapped RandomDevice as a Source, counting the entropy of a uniform choice among n
+func (e *EntropyCounter) IntN(n int) int {
+	if n > 1 {
+		e.AddBits(math.Log2(float64(n)))
+	}
+	return SourceOf(e.RandomDevice).IntN(n)
+}
+
+// Uint64 delegates to the wrapped RandomDevice as a Source, it is not counted
+func (e *EntropyCounter) Uint64() uint64 {
+	return SourceOf(e.RandomDevice).Uint64()
+}
+
+// Float64 delegates to the wrapped RandomDevice as a Source, it is not counted
+func (e *EntropyCounter) Float64() float64 {
+	return SourceOf(e.RandomDevice).Float64()
+}
+
+// AddBits adds bits of entropy to the count, of the kind of decisions given to Decide
+func (e *EntropyCounter) AddBits(bits float64) {
+	e.bits[e.decides()] += bits
+}
+
+// Decide sets the kinds of the decisions counted from now on, they are TextDecisions until Decide is called
+func (e *EntropyCounter) Decide(decisions Decisions) {
+	e.decision = decisions
+}
+
+// decides returns the kinds of the decisions counted
+func (e *EntropyCounter) decides() Decisions {
+	if e.decision == 0 {
+		return TextDecisions
+	}
+	return e.decision
+}
+
+// Erase drops the bits of the decisions counted so far of any of the kinds erased.
+// Bits counted while deciding more than one kind are dropped if any of those kinds is erased
+func (e *EntropyCounter) Erase(erased Decisions) {
+	for decisions := range e.bits {
+		if Decisions(decisions)&erased != 0 {
+			e.bits[decisions] = 0
+		}
+	}
+}
+
+// Bits returns the bits of entropy counted since creation or the last Reset, that are not erased
+func (e *EntropyCounter) Bits() (bits float64) {
+	for _, b := range e.bits {
+		bits += b
+	}
+	return bits
+}
+
+// Reset sets the count back to zero, deciding TextDecisions
+func (e *EntropyCounter) Reset() {
+	e.bits = [AllDecisions + 1]float64{}
+	e.decision = 0
+}
+
+// entropyRecorder is a RandomDevice that accepts the entropy of a decision, fx EntropyCounter
+type entropyRecorder interface {
+	AddBits(float64)
+}
+
+// recordDecision records the entropy of a decision at the given threshold
+// on the RandomDevice, if it is an entropyRecorder
+func recordDecision(device RandomDevice, threshold float64) {
+	if recorder, ok := device.(entropyRecorder); ok {
+		recorder.AddBits(binaryEntropy(threshold))
+	}
+}
+
+// binaryEntropy is the entropy, in bits, of a decision that is true with probability p
+func binaryEntropy(p float64) float64 {
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
+
+// randAbove reports whether a random number of the RandomDevice is above the threshold
+func randAbove(device RandomDevice, threshold float64) bool {
+	recordDecision(device, threshold)
+	return SourceOf(device).Float64() > threshold
+}
+
+// randBelow reports whether a random number of the RandomDevice is below the threshold
+func randBelow(device RandomDevice, threshold float64) bool {
+	recordDecision(device, threshold)
+	return SourceOf(device).Float64() < threshold
+}
+
+// Decides is CaseDecisions
+func (TitleFormatter) Decides() Decisions {
+	return CaseDecisions
+}
+
+// Decides is CaseDecisions
+func (UppercaseCharFormatter) Decides() Decisions {
+	return CaseDecisions
+}
+
+// Erases is CaseDecisions, the letters are uppercase
+func (UppercaseCharFormatter) Erases() Decisions {
+	return CaseDecisions
+}
+
+// Decides is CaseDecisions
+func (LowercaseCharFormatter) Decides() Decisions {
+	return CaseDecisions
+}
+
+// Erases is CaseDecisions, the letters are lowercase
+func (LowercaseCharFormatter) Erases() Decisions {
+	return CaseDecisions
+}
+
+// Decides is CaseDecisions
+func (SwitchCaseCharFormatter) Decides() Decisions {
+	return CaseDecisions
+}
+
+// Erases is CaseDecisions, the replaced letters have no case
+func (L337CharFormatter) Erases() Decisions {
+	return CaseDecisions
+}
+
+// Erases is AllDecisions, the letters are replaced by random symbols
+func (swearCharFormatter) Erases() Decisions {
+	return AllDecisions
+}
+
+// Erases is AllDecisions, the words are replaced by random horse words
+func (HorseFormatter) Erases() Decisions {
+	return AllDecisions
+}
+
+// Erases is CaseDecisions, Morse code has no case
+func (MorseFormatter) Erases() Decisions {
+	return CaseDecisions
+}
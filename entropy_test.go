@@ -0,0 +1,81 @@
+package profaneword
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEntropyCounter_RandMax(t *testing.T) {
+	e := NewEntropyCounter(zeroRandomDevice(0))
+	e.RandMax(8)
+	e.RandMax(1)
+	if e.Bits() != 3 {
+		t.Errorf("expected 3 bits of entropy from a choice among 8, got %f", e.Bits())
+	}
+	e.Reset()
+	if e.Bits() != 0 {
+		t.Errorf("expected Reset to zero the count")
+	}
+}
+
+func TestEntropyCounter_Decisions(t *testing.T) {
+	e := NewEntropyCounter(zeroRandomDevice(0))
+	sarcastic := NewSarcasticFormatter(WithRandom(e))
+	sarcastic.Format("asd")
+	if e.Bits() != 3 {
+		t.Errorf("expected one bit of entropy per 50:50 decision, got %f", e.Bits())
+	}
+	e.Reset()
+	FastFingerCharFormatter{e}.FormatRune('a')
+	if expected := binaryEntropy(1. / 6); math.Abs(e.Bits()-expected) > 1e-9 {
+		t.Errorf("expected %f bits of entropy of a 1/6 decision, got %f", expected, e.Bits())
+	}
+}
+
+func TestEntropyCounter_Erase(t *testing.T) {
+	e := NewEntropyCounter(zeroRandomDevice(0))
+	e.RandMax(8)
+	e.Decide(CaseDecisions)
+	e.RandMax(4)
+	e.Decide(AllDecisions)
+	e.RandMax(2)
+	if e.Bits() != 6 {
+		t.Errorf("expected 6 bits of entropy before erasing, got %f", e.Bits())
+	}
+	e.Erase(CaseDecisions)
+	if e.Bits() != 3 {
+		t.Errorf("expected erasing the case to drop the bits of every decision on the case, got %f", e.Bits())
+	}
+	e.Erase(TextDecisions)
+	if e.Bits() != 0 {
+		t.Errorf("expected erasing the text to drop the rest, got %f", e.Bits())
+	}
+}
+
+func TestErasedBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+		decides   Decisions
+		erases    Decisions
+	}{
+		{"title", RandomTitleFormatter(), CaseDecisions, 0},
+		{"whisper", NewLowercaseFormatter(), CaseDecisions, CaseDecisions},
+		{"randomly whisper", NewRandomlyFormatter(NewLowercaseFormatter()), CaseDecisions, 0},
+		{"perword SCREAM", NewPerWordFormatter(NewUppercaseFormatter()), CaseDecisions, CaseDecisions},
+		{"/s", NewSarcasticFormatter(), CaseDecisions, 0},
+		{"1337", L337Formatter(), TextDecisions, CaseDecisions},
+		{"horse", NewHorseFormatter(), TextDecisions, AllDecisions},
+		{"swear", NewSwearFormatter(), TextDecisions, AllDecisions},
+		{"shuffle", NewShuffleFormatter(), TextDecisions, 0},
+		{"in order", &MultiFormatter{Formatters: []Formatter{NewSarcasticFormatter(), NewShuffleFormatter(), NewLowercaseFormatter()}}, AllDecisions, CaseDecisions},
+	}
+	for _, test := range tests {
+		if decides := DecisionsOf(test.formatter); decides != test.decides {
+			t.Errorf("%s: expected the decisions %d, got %d", test.name, test.decides, decides)
+		}
+		if erases := ErasedBy(test.formatter); erases != test.erases {
+			t.Errorf("%s: expected to erase %d, got %d", test.name, test.erases, erases)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -78,7 +79,7 @@ var _ Formatter = &CharFormatterDelegatingFormatter{}
 
 // Format calls the wrapped CharFormatter's FormatRune-method
 func (c *CharFormatterDelegatingFormatter) Format(word string) string {
-	var out []rune
+	out := make([]rune, 0, len(word))
 	for _, r := range word {
 		out = append(out, c.FormatRune(r)...)
 	}
@@ -98,6 +99,14 @@ func (c *CharFormatterDelegatingFormatter) GetCharFormatter() CharFormatter {
 // PerWordFormattingFormatter delegates to another Formatter, but calls the Format method for each word
 type PerWordFormattingFormatter struct {
 	Other Formatter
+	// Tokenizer splits the text into words, the default is WhitespaceTokenizer
+	Tokenizer Tokenizer
+}
+
+// NewPerWordFormatter returns a PerWordFormattingFormatter of the Formatter, splitting words by the Tokenizer given by WithTokenizer
+func NewPerWordFormatter(f Formatter, opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &PerWordFormattingFormatter{Other: f, Tokenizer: o.tokenizer}
 }
 
 // SetFormatter sets the Formatter that PerWordFormattingFormatter should wrap
@@ -110,14 +119,24 @@ func (p *PerWordFormattingFormatter) GetFormatter() Formatter {
 	return p.Other
 }
 
-// Format splits the text on space, and calls Other > Format for each word, then joining the text again.
-func (p *PerWordFormattingFormatter) Format(word string) string {
-	words := strings.Split(word, ` `)
-	ws := make([]string, len(words))
-	for i, word := range words {
-		ws[i] = p.Other.Format(word)
+// Format splits the text using the Tokenizer, and calls Other > Format for each word,
+// then joining the text again with the separators kept verbatim.
+func (p *PerWordFormattingFormatter) Format(text string) string {
+	tokenizer := p.Tokenizer
+	if tokenizer == nil {
+		tokenizer = WhitespaceTokenizer{}
+	}
+	text = validUTF8(text)
+	sb := strings.Builder{}
+	sb.Grow(len(text))
+	for _, token := range tokenizer.Tokenize(text) {
+		if token.Word {
+			sb.WriteString(p.Other.Format(token.Text))
+		} else {
+			sb.WriteString(token.Text)
+		}
 	}
-	return strings.Join(ws, ` `)
+	return sb.String()
 }
 
 // RandomlyFormattingFormatter is a formatter that formats at a rate of 50%
@@ -130,7 +149,7 @@ var _ Formatter = &RandomlyFormattingFormatter{}
 
 // Format calls the Other > Format at a rate of 50%
 func (rff *RandomlyFormattingFormatter) Format(word string) string {
-	if rff.Rand.Rand().Cmp(rff.Threshold) > 0 {
+	if randAbove(rff.Rand, rff.threshold()) {
 		return rff.Other.Format(word)
 	}
 	return word
@@ -147,11 +166,13 @@ func (rff *RandomlyFormattingFormatter) GetFormatter() Formatter {
 }
 
 // NewRandomlyFormatter is a method that wraps a given Formatter,
-// with the RandomlyFormattingFormatter that is wrapped with a PerWordFormattingFormatter
-func NewRandomlyFormatter(wrap Formatter) Formatter {
-	random := &RandomlyFormattingFormatter{thresholdRandom: newFiftyFifty()}
+// with the RandomlyFormattingFormatter that is wrapped with a PerWordFormattingFormatter.
+// The rate is 50% unless given by WithThreshold
+func NewRandomlyFormatter(wrap Formatter, opts ...Option) Formatter {
+	o := newOptions(opts)
+	random := &RandomlyFormattingFormatter{thresholdRandom: o.thresholdRandom()}
 	random.SetFormatter(wrap)
-	return &PerWordFormattingFormatter{random}
+	return &PerWordFormattingFormatter{Other: random, Tokenizer: o.tokenizer}
 }
 
 // TitleFormatter is a Formatter that Titles the given text
@@ -167,8 +188,8 @@ func (TitleFormatter) Format(word string) string {
 }
 
 // RandomTitleFormatter returns a formatter that titles only every other time
-func RandomTitleFormatter() Formatter {
-	return NewRandomlyFormatter(TitleFormatter{})
+func RandomTitleFormatter(opts ...Option) Formatter {
+	return NewRandomlyFormatter(TitleFormatter{}, opts...)
 }
 
 var _ Formatter = &RandomlyFormattingFormatter{}
@@ -197,20 +218,22 @@ func DelimiterFormatterWith(repl string) Formatter {
 // ReversingFormatter reverses the string
 type ReversingFormatter struct{}
 
-// Format reverses the input text
+// Format reverses the input text, by its grapheme clusters, such that combining marks and emoji sequences are kept whole
 func (ReversingFormatter) Format(text string) string {
-	l := len(text)
-	reversed := make([]rune, l)
-	for i, t := range text {
-		reversed[l-i-1] = t
+	clusters := graphemes(text)
+	sb := strings.Builder{}
+	sb.Grow(len(text))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		sb.WriteString(clusters[i])
 	}
-	return string(reversed)
+	return sb.String()
 }
 
 // NewWordReversingFormatter returns a ReversingFormatter that reverses each words in a group,
 // and not the entire text as one
-func NewWordReversingFormatter() Formatter {
-	return &PerWordFormattingFormatter{ReversingFormatter{}}
+func NewWordReversingFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &PerWordFormattingFormatter{Other: ReversingFormatter{}, Tokenizer: o.tokenizer}
 }
 
 type swearFormatter struct {
@@ -231,33 +254,31 @@ var _ Formatter = &swearFormatter{swearCharFormatter{}}
 var _ WrappingCharFormatter = &swearFormatter{swearCharFormatter{}}
 
 // Format of swearFormatter will return the input string if the input does not start with a letter,
-// for all other cases it replaces using the wrapped CharFormatter until it meets a non-letter character
+// for all other cases it replaces each grapheme cluster using the wrapped CharFormatter until it meets a non-letter
 func (s *swearFormatter) Format(word string) string {
-	if !unicode.IsLetter(rune(word[0])) {
+	word = validUTF8(word)
+	if r, _ := utf8.DecodeRuneInString(word); !unicode.IsLetter(r) {
 		return word
 	}
 	var runes []rune
-	var i int
-	var c rune
-	for i, c = range word {
-		if unicode.IsLetter(c) {
-			runes = append(runes, s.FormatRune(c)...)
-		} else {
+	clusters := graphemes(word)
+	i := 0
+	for ; i < len(clusters); i++ {
+		c, _ := utf8.DecodeRuneInString(clusters[i])
+		if !unicode.IsLetter(c) {
 			break
 		}
+		runes = append(runes, s.FormatRune(c)...)
 	}
-	suffix := ""
-	if i < len(word)-1 {
-		suffix = word[i:]
-	}
-	return string(runes) + `!` + suffix
+	return string(runes) + `!` + strings.Join(clusters[i:], "")
 }
 
 // NewSwearFormatter reuturns a Formatter that replaces each character in a word with cartoonish swear
-func NewSwearFormatter() Formatter {
-	return &PerWordFormattingFormatter{&swearFormatter{&swearCharFormatter{
-		CryptoRand{},
-	}}}
+func NewSwearFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &PerWordFormattingFormatter{Other: &swearFormatter{&swearCharFormatter{
+		o.rand,
+	}}, Tokenizer: o.tokenizer}
 }
 
 // StudderFormatter is a formatter that writes text that appear like it's studdering
@@ -265,15 +286,17 @@ type StudderFormatter struct {
 	RandomDevice
 }
 
-// Format will return the first character of a word plus '-' up to 4 times, and the word
+// Format will return the first character (grapheme cluster) of a word plus '-' up to 4 times, and the word
 func (s StudderFormatter) Format(word string) string {
-	if !unicode.IsLetter(rune(word[0])) {
+	word = validUTF8(word)
+	if r, _ := utf8.DecodeRuneInString(word); !unicode.IsLetter(r) {
 		return word
 	}
+	first := graphemes(word)[0]
 	numStudder := s.RandMax(4)
 	sb := strings.Builder{}
 	for i := 0; i < numStudder; i++ {
-		sb.WriteString(word[:1] + "-")
+		sb.WriteString(first + "-")
 	}
 	return sb.String() + word
 }
@@ -281,8 +304,9 @@ func (s StudderFormatter) Format(word string) string {
 var _ Formatter = StudderFormatter{}
 
 // NewStudderFormatter returns a PerWordFormattingFormatter that wraps a StudderFormatter
-func NewStudderFormatter() Formatter {
-	return &PerWordFormattingFormatter{StudderFormatter{CryptoRand{}}}
+func NewStudderFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &PerWordFormattingFormatter{Other: StudderFormatter{o.rand}, Tokenizer: o.tokenizer}
 }
 
 // HorseFormatter returns horse-related banter for each call
@@ -347,8 +371,9 @@ func (s HorseFormatter) Format(_ string) string {
 var _ Formatter = HorseFormatter{}
 
 // NewHorseFormatter returns a PerWordFormattingFormatter wrapping a HorseFormatter
-func NewHorseFormatter() Formatter {
-	return &PerWordFormattingFormatter{HorseFormatter{CryptoRand{}}}
+func NewHorseFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &PerWordFormattingFormatter{Other: HorseFormatter{o.rand}, Tokenizer: o.tokenizer}
 }
 
 // ShuffleFormatter shuffles the given string
@@ -356,22 +381,24 @@ type ShuffleFormatter struct {
 	RandomDevice
 }
 
-// Format shuffles the characters of an input string and returns a new shuffled string
+// Format shuffles the characters (grapheme clusters) of an input string and returns a new shuffled string
 func (s ShuffleFormatter) Format(text string) string {
-	word := []byte(text)
+	word := graphemes(text)
 	wlen := len(word)
-	shuffledWord := make([]byte, wlen)
+	sb := strings.Builder{}
+	sb.Grow(len(text))
 	for i := 0; i < wlen; i++ {
 		idx := s.RandMax(wlen - i)
-		shuffledWord[i] = word[idx]
+		sb.WriteString(word[idx])
 		word[idx] = word[wlen-1-i]
 	}
-	return string(shuffledWord)
+	return sb.String()
 }
 
 var _ Formatter = ShuffleFormatter{}
 
 // NewShuffleFormatter returns a PerWordFormattingFormatter wrapping a ShuffleFormatter
-func NewShuffleFormatter() Formatter {
-	return &PerWordFormattingFormatter{ShuffleFormatter{CryptoRand{}}}
+func NewShuffleFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &PerWordFormattingFormatter{Other: ShuffleFormatter{o.rand}, Tokenizer: o.tokenizer}
 }
@@ -1,9 +1,13 @@
 package profaneword
 
 import (
+	"crypto/rand"
 	"fmt"
+	"math"
 	"math/big"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestShuffleFormatter_Format(t *testing.T) {
@@ -87,7 +91,7 @@ func TestShuffleFormatter_Format_NonRandom(t *testing.T) {
 
 func TestStudderFormatter_Format(t *testing.T) {
 	in := "zero one two three four zero"
-	s := PerWordFormattingFormatter{StudderFormatter{&countRandomDevice{}}}
+	s := PerWordFormattingFormatter{Other: StudderFormatter{&countRandomDevice{}}}
 	got := s.Format(in)
 	expected := "zero o-one t-t-two t-t-t-three f-f-f-f-four zero"
 	if got != expected {
@@ -196,3 +200,164 @@ func TestMultiFormatter_Format(t *testing.T) {
 		t.Errorf("unexpected formatting of MultiFormatter")
 	}
 }
+
+func TestWithThreshold(t *testing.T) {
+	always := NewRandomlyFormatter(appendingFormatter("!"), WithRandom(zeroRandomDevice(1)), WithThreshold(big.NewRat(0, 1)))
+	if got := always.Format("a b"); got != "a! b!" {
+		t.Errorf("expected a threshold of 0 to always format, got %s", got)
+	}
+	never := NewRandomlyFormatter(appendingFormatter("!"), WithRandom(zeroRandomDevice(1)), WithThreshold(big.NewRat(1, 1)))
+	if got := never.Format("a b"); got != "a b" {
+		t.Errorf("expected a threshold of 1 to never format, got %s", got)
+	}
+}
+
+func TestRandomlyFormattingFormatter_Threshold(t *testing.T) {
+	rff := &RandomlyFormattingFormatter{Other: appendingFormatter("!")}
+	rff.Rand = zeroRandomDevice(1)
+	rff.Threshold = big.NewRat(0, 1)
+	if got := rff.Format("a"); got != "a!" {
+		t.Errorf("expected a Threshold set on the struct to be used, got %s", got)
+	}
+	rff.Threshold = big.NewRat(1, 1)
+	if got := rff.Format("a"); got != "a" {
+		t.Errorf("expected a replaced Threshold to be used, got %s", got)
+	}
+	rff.Threshold.SetInt64(0)
+	if got := rff.Format("a"); got != "a!" {
+		t.Errorf("expected a Threshold changed in place to be used, got %s", got)
+	}
+}
+
+func TestWithRandom(t *testing.T) {
+	h := NewHorseFormatter(WithRandom(&countRandomDevice{}))
+	if got := h.Format("a b"); got != horsewords[0]+" "+horsewords[1] {
+		t.Errorf("expected the given RandomDevice to be used, got %s", got)
+	}
+}
+
+// bigCryptoRand is the RandomDevice as it was, before Source; allocating big.Int and big.Rat for each call
+type bigCryptoRand struct{}
+
+func (bigCryptoRand) Rand() *big.Rat {
+	max := big.NewInt(math.MaxInt64)
+	i, _ := rand.Int(rand.Reader, max)
+	return big.NewRat(i.Int64(), max.Int64())
+}
+
+func (bigCryptoRand) RandMax(max int) int {
+	i, _ := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	return int(i.Int64())
+}
+
+var _ RandomDevice = bigCryptoRand{}
+
+// obscureInput is a multi-megabyte text, as would be piped to obscure
+var obscureInput = strings.Repeat("a quick brown fox jumps over the lazy dog\n", 1<<16)
+
+func benchmarkObscure(b *testing.B, device RandomDevice) {
+	mf := MultiFormatter{}
+	mf.With(NewSarcasticFormatter(WithRandom(device)))
+	mf.With(NewFatFingerFormatter(WithRandom(device)))
+	b.SetBytes(int64(len(obscureInput)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mf.Format(obscureInput)
+	}
+}
+
+func BenchmarkObscure_CryptoRand(b *testing.B) {
+	benchmarkObscure(b, CryptoRand{})
+}
+
+func BenchmarkObscure_SeededRand(b *testing.B) {
+	benchmarkObscure(b, NewSeededRand([]byte("benchmark")))
+}
+
+func BenchmarkObscure_BigRandomDevice(b *testing.B) {
+	benchmarkObscure(b, bigCryptoRand{})
+}
+
+func TestReversingFormatter_Format_Graphemes(t *testing.T) {
+	r := ReversingFormatter{}
+	if got := r.Format("Ødelagt"); got != "tgaledØ" {
+		t.Errorf("expected multi-byte text to reverse, got %q", got)
+	}
+	if got := r.Format("née 👍🏽"); got != "👍🏽 eén" {
+		t.Errorf("expected grapheme clusters to be kept whole, got %q", got)
+	}
+}
+
+func TestStudderFormatter_Format_Graphemes(t *testing.T) {
+	s := StudderFormatter{maxRandomDevice{}}
+	if got := s.Format("Ødelagt"); got != "Ø-Ø-Ø-Ødelagt" {
+		t.Errorf("expected the first character to be studdered, got %q", got)
+	}
+	if got := s.Format(""); got != "" {
+		t.Errorf("expected an empty word to be returned, got %q", got)
+	}
+}
+
+func TestSwearFormatter_Format_Graphemes(t *testing.T) {
+	sf := swearFormatter{UnitFormatter{}}
+	if got := sf.Format("Ødelagt-ø"); got != "Ødelagt!-ø" {
+		t.Errorf("expected the suffix to be kept, got %q", got)
+	}
+	if got := sf.Format("asd-"); got != "asd!-" {
+		t.Errorf("expected the suffix to be kept, got %q", got)
+	}
+	if got := sf.Format(""); got != "" {
+		t.Errorf("expected an empty word to be returned, got %q", got)
+	}
+}
+
+// fuzzFormatter asserts that the Formatter never panics and always returns valid UTF-8
+func fuzzFormatter(f *testing.F, formatter Formatter) {
+	for _, seed := range []string{"", "a quick brown fox", "Ødelagt", "Straße", "é", "👩‍👩‍👧", "🇩🇰🇩", "\xff\xfe", "a\r\nb\t c"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		if got := formatter.Format(text); !utf8.ValidString(got) {
+			t.Errorf("invalid UTF-8 formatting %q: %q", text, got)
+		}
+	})
+}
+
+func FuzzReversingFormatter(f *testing.F) {
+	fuzzFormatter(f, ReversingFormatter{})
+}
+
+func FuzzReversingFormatter_Length(f *testing.F) {
+	f.Add("Ødelagt 👍🏽 é")
+	f.Fuzz(func(t *testing.T, text string) {
+		if !utf8.ValidString(text) {
+			return
+		}
+		if got := (ReversingFormatter{}).Format(text); len(got) != len(text) {
+			t.Errorf("expected reversing to keep the length, %q became %q", text, got)
+		}
+	})
+}
+
+func FuzzShuffleFormatter(f *testing.F) {
+	fuzzFormatter(f, ShuffleFormatter{CryptoRand{}})
+}
+
+func FuzzWordReversingFormatter(f *testing.F) {
+	fuzzFormatter(f, NewWordReversingFormatter())
+}
+
+func FuzzSwearFormatter(f *testing.F) {
+	fuzzFormatter(f, NewSwearFormatter())
+}
+
+func FuzzStudderFormatter(f *testing.F) {
+	fuzzFormatter(f, NewStudderFormatter())
+}
+
+func TestNewPerWordFormatter(t *testing.T) {
+	formatter := NewPerWordFormatter(ReversingFormatter{}, WithTokenizer(WordTokenizer{}))
+	if got := formatter.Format("sex-fucker! you"); got != "xes-rekcuf! uoy" {
+		t.Errorf("expected each word to be reversed, got %q", got)
+	}
+}
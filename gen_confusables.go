@@ -0,0 +1,183 @@
+//go:build ignore
+
+// gen_confusables generates confusables_table.go, the homoglyphs of the Latin letters, of the Unicode confusables data.
+// A homoglyph of a letter is a single letter of another script, or a Roman numeral, that has the prototype of the letter,
+// fx а (Cyrillic a) and ɑ (Latin alpha) are a, and І (Cyrillic I) is l, the prototype of I.
+// A letter with a prototype of a sequence of letters has the sequence as a homoglyph, fx rn for m.
+// The fullwidth forms and mathematical alphanumerics are left out, homoglyphsOf adds those.
+//
+// By default it reads the excerpt in testdata, give -in to generate of the full file:
+//
+//	go run gen_confusables.go -in confusables.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	in  = flag.String("in", "testdata/confusables.txt", "the confusables.txt file to read")
+	out = flag.String("out", "confusables_table.go", "the Go file to write")
+)
+
+// confusable is a line of confusables.txt, the source text and its prototype
+type confusable struct {
+	source, prototype string
+}
+
+func main() {
+	flag.Parse()
+	version, confusables, err := read(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	table := homoglyphs(confusables)
+	src, err := generate(version, table)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// read returns the version and the lines of the confusables.txt file
+func read(name string) (version string, confusables []confusable, err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimPrefix(scanner.Text(), "\uFEFF")
+		if strings.HasPrefix(line, "# Version:") {
+			version = strings.TrimSpace(strings.TrimPrefix(line, "# Version:"))
+		}
+		line, _, _ = strings.Cut(line, "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 3 {
+			return "", nil, fmt.Errorf("%s:%d: expected three fields", name, n)
+		}
+		source, err := codePoints(fields[0])
+		if err != nil {
+			return "", nil, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+		prototype, err := codePoints(fields[1])
+		if err != nil {
+			return "", nil, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+		confusables = append(confusables, confusable{source, prototype})
+	}
+	if version == "" {
+		return "", nil, fmt.Errorf("%s: no version", name)
+	}
+	return version, confusables, scanner.Err()
+}
+
+// codePoints returns the text of the space separated hexadecimal code points
+func codePoints(field string) (string, error) {
+	var sb strings.Builder
+	for _, hex := range strings.Fields(field) {
+		r, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteRune(rune(r))
+	}
+	return sb.String(), nil
+}
+
+// homoglyphs returns the homoglyphs of the Latin letters, sorted by code point
+func homoglyphs(confusables []confusable) map[rune][]string {
+	prototypes := make(map[string]string, len(confusables))
+	for _, c := range confusables {
+		prototypes[c.source] = c.prototype
+	}
+	letters := map[string][]rune{} // the letters of each prototype, fx I and l of l
+	table := map[rune][]string{}
+	for _, first := range []rune{'A', 'a'} {
+		for letter := first; letter < first+26; letter++ {
+			prototype, ok := prototypes[string(letter)]
+			if !ok {
+				prototype = string(letter)
+			}
+			letters[prototype] = append(letters[prototype], letter)
+			if ok && len(prototype) > 1 {
+				table[letter] = append(table[letter], prototype)
+			}
+		}
+	}
+	for _, c := range confusables {
+		r, size := utf8.DecodeRuneInString(c.source)
+		if size != len(c.source) || r < utf8.RuneSelf || 0xFF00 <= r && r <= 0xFFEF || 0x1D400 <= r && r <= 0x1D7FF ||
+			!unicode.IsLetter(r) && !unicode.Is(unicode.Nl, r) {
+			continue // a sequence, ASCII, fullwidth, mathematical or not a letter
+		}
+		candidates := letters[c.prototype]
+		if len(candidates) == 0 {
+			continue
+		}
+		letter := candidates[0]
+		for _, candidate := range candidates {
+			if unicode.IsUpper(candidate) == isUpper(r) {
+				letter = candidate
+			}
+		}
+		table[letter] = append(table[letter], c.source)
+	}
+	for _, homoglyphs := range table {
+		sort.Slice(homoglyphs, func(i, j int) bool {
+			return homoglyphs[i] < homoglyphs[j]
+		})
+	}
+	return table
+}
+
+// isUpper reports whether the rune is an uppercase letter, or an uppercase Roman numeral
+func isUpper(r rune) bool {
+	return unicode.IsUpper(r) || unicode.Is(unicode.Other_Uppercase, r)
+}
+
+// generate returns the formatted source of confusables_table.go
+func generate(version string, table map[rune][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_confusables.go of confusables.txt, Version: %s; DO NOT EDIT.\n\n", version)
+	fmt.Fprintf(&buf, "package profaneword\n\n")
+	fmt.Fprintf(&buf, "// confusablesVersion is the version of the Unicode confusables data of confusables\n")
+	fmt.Fprintf(&buf, "const confusablesVersion = %q\n\n", version)
+	fmt.Fprintf(&buf, "// confusables are the homoglyphs of the Latin letters of the Unicode confusables data\n")
+	fmt.Fprintf(&buf, "var confusables = map[rune][]string{\n")
+	for _, first := range []rune{'A', 'a'} {
+		for letter := first; letter < first+26; letter++ {
+			if len(table[letter]) == 0 {
+				continue
+			}
+			fmt.Fprintf(&buf, "%q: {\n", letter)
+			for _, homoglyph := range table[letter] {
+				if quoted := strconv.QuoteToASCII(homoglyph); quoted == strconv.Quote(homoglyph) {
+					fmt.Fprintf(&buf, "%s,\n", quoted)
+				} else {
+					fmt.Fprintf(&buf, "%s, // %s\n", quoted, homoglyph)
+				}
+			}
+			fmt.Fprintf(&buf, "},\n")
+		}
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,200 @@
+package profaneword
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemes splits the text into its extended grapheme clusters, user-perceived characters, following
+// the rules of Unicode UAX #29 for: CR LF, controls, Hangul syllables, extending and spacing marks, prepended
+// characters, zero width joiner emoji sequences, emoji modifiers and regional indicator pairs (flags).
+// Invalid UTF-8 is replaced by utf8.RuneError.
+//
+// The rules are exact, but the properties they apply to are approximated by the tables of the unicode package,
+// as the module has no tables of the grapheme break properties. It differs from UAX #29 in that:
+//   - Extended_Pictographic is every code point of 1F000-1FAFF, other than regional indicators and emoji modifiers,
+//     the symbols (So, Sm and Pd) of the arrows, technical, shapes, dingbats and symbols blocks, and a few
+//     single code points; a pictographic elsewhere, fx Ⓜ U+24C2, does not join a following zero width joiner sequence
+//   - Extend is Mn, Me, zero width non-joiner, emoji modifiers and tags, it misses Other_Grapheme_Extend
+//     that is not Mc, fx the halfwidth katakana sound marks U+FF9E and U+FF9F, that are split off
+//   - SpacingMark is Mc, and Thai and Lao sara am, it is not split from the preceding character either way
+//   - the Indic conjunct rule of Unicode 15.1 (GB9c) is not applied, fx क्ष is split into क् and ष
+func graphemes(text string) []string {
+	text = validUTF8(text)
+	var clusters []string
+	var state graphemeState
+	start := 0
+	for i, r := range text {
+		if i > 0 && state.isBoundary(r) {
+			clusters = append(clusters, text[start:i])
+			start = i
+		}
+		state.next(r)
+	}
+	if start < len(text) {
+		clusters = append(clusters, text[start:])
+	}
+	return clusters
+}
+
+// validUTF8 returns the text with invalid UTF-8 replaced by utf8.RuneError
+func validUTF8(text string) string {
+	return strings.ToValidUTF8(text, string(utf8.RuneError))
+}
+
+const (
+	zwj  = '\u200d' // zero width joiner
+	zwnj = '\u200c' // zero width non-joiner
+)
+
+// graphemeState is the state needed to find the grapheme boundary before a rune
+type graphemeState struct {
+	prev rune
+	// riCount is the number of consecutive regional indicators up to and including prev
+	riCount int
+	// pictographic is whether prev is an extended pictographic, possibly followed by extending runes
+	pictographic bool
+	// pictographicZWJ is whether prev is a zero width joiner following a pictographic
+	pictographicZWJ bool
+}
+
+func (g *graphemeState) next(r rune) {
+	if isRegionalIndicator(r) {
+		g.riCount++
+	} else {
+		g.riCount = 0
+	}
+	g.pictographicZWJ = r == zwj && g.pictographic
+	g.pictographic = isExtendedPictographic(r) || g.pictographic && isGraphemeExtend(r)
+	g.prev = r
+}
+
+// isBoundary reports whether there is a grapheme boundary between the previous rune and r
+func (g *graphemeState) isBoundary(r rune) bool {
+	prev := g.prev
+	switch {
+	case prev == '\r' && r == '\n':
+		return false
+	case isControl(prev) || isControl(r):
+		return true
+	case isHangulSequence(prev, r):
+		return false
+	case isGraphemeExtend(r) || r == zwj || isSpacingMark(r):
+		return false
+	case isPrepend(prev):
+		return false
+	case g.pictographicZWJ && isExtendedPictographic(r):
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		return g.riCount%2 == 0
+	}
+	return true
+}
+
+// isControl reports whether the rune is of the Control, CR or LF grapheme property
+func isControl(r rune) bool {
+	return r != zwj && r != zwnj && (unicode.IsControl(r) || unicode.In(r, unicode.Zl, unicode.Zp) ||
+		unicode.Is(unicode.Cf, r) && !isGraphemeExtend(r) && !isPrepend(r))
+}
+
+// prepend is the Prepend grapheme property, characters that join the character following them,
+// fx the Arabic number signs and the Malayalam dot reph
+var prepend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0600, Hi: 0x0605, Stride: 1},
+		{Lo: 0x06DD, Hi: 0x06DD, Stride: 1},
+		{Lo: 0x070F, Hi: 0x070F, Stride: 1},
+		{Lo: 0x0890, Hi: 0x0891, Stride: 1},
+		{Lo: 0x08E2, Hi: 0x08E2, Stride: 1},
+		{Lo: 0x0D4E, Hi: 0x0D4E, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x110BD, Hi: 0x110BD, Stride: 1},
+		{Lo: 0x110CD, Hi: 0x110CD, Stride: 1},
+		{Lo: 0x111C2, Hi: 0x111C3, Stride: 1},
+		{Lo: 0x1193F, Hi: 0x1193F, Stride: 1},
+		{Lo: 0x11941, Hi: 0x11941, Stride: 1},
+		{Lo: 0x11A3A, Hi: 0x11A3A, Stride: 1},
+		{Lo: 0x11A84, Hi: 0x11A89, Stride: 1},
+		{Lo: 0x11D46, Hi: 0x11D46, Stride: 1},
+		{Lo: 0x11F02, Hi: 0x11F02, Stride: 1},
+	},
+}
+
+func isPrepend(r rune) bool {
+	return unicode.Is(prepend, r)
+}
+
+// isSpacingMark approximates the SpacingMark property by Mc, and Thai and Lao sara am
+func isSpacingMark(r rune) bool {
+	return unicode.Is(unicode.Mc, r) || r == 0x0E33 || r == 0x0EB3
+}
+
+// isGraphemeExtend approximates the Grapheme_Extend property; marks, zero width non-joiner,
+// emoji modifiers and tags
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) ||
+		r == zwnj ||
+		r >= 0x1F3FB && r <= 0x1F3FF ||
+		r >= 0xE0020 && r <= 0xE007F
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isExtendedPictographic approximates the Extended_Pictographic property by the emoji blocks
+func isExtendedPictographic(r rune) bool {
+	switch {
+	case r == 0xA9, r == 0xAE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139:
+		return true
+	case r >= 0x2190 && r <= 0x21FF, r >= 0x2300 && r <= 0x23FF, r >= 0x25A0 && r <= 0x27BF,
+		r >= 0x2900 && r <= 0x297F, r >= 0x2B00 && r <= 0x2BFF, r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return unicode.Is(unicode.So, r) || unicode.Is(unicode.Sm, r) || unicode.Is(unicode.Pd, r)
+	case r >= 0x1F000 && r <= 0x1FAFF && !isRegionalIndicator(r) && !(r >= 0x1F3FB && r <= 0x1F3FF):
+		return true
+	}
+	return false
+}
+
+// Hangul syllable types, per UAX #29
+const (
+	hangulNone = iota
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+func hangulType(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return hangulL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return hangulV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return hangulT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+	return hangulNone
+}
+
+// isHangulSequence reports whether prev and r are parts of the same Hangul syllable
+func isHangulSequence(prev, r rune) bool {
+	p, c := hangulType(prev), hangulType(r)
+	switch p {
+	case hangulL:
+		return c == hangulL || c == hangulV || c == hangulLV || c == hangulLVT
+	case hangulLV, hangulV:
+		return c == hangulV || c == hangulT
+	case hangulLVT, hangulT:
+		return c == hangulT
+	}
+	return false
+}
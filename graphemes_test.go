@@ -0,0 +1,89 @@
+package profaneword
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := map[string][]string{
+		"":              nil,
+		"asd":           {"a", "s", "d"},
+		"Ødelagt":       {"Ø", "d", "e", "l", "a", "g", "t"},
+		"été":         {"é", "t", "é"},
+		"a\r\nb":        {"a", "\r\n", "b"},
+		"👍🏽!":           {"👍🏽", "!"},
+		"👩‍👩‍👧 x":       {"👩‍👩‍👧", " ", "x"},
+		"🇩🇰🇩🇪🇩":         {"🇩🇰", "🇩🇪", "🇩"},
+		"각각":          {"각", "각"},
+		"a\xffb":        {"a", "�", "b"},
+		"x‍":            {"x‍"},
+		"❤️‍\U0001F525": {"❤️‍\U0001F525"},
+	}
+	for text, expected := range tests {
+		if got := graphemes(text); !reflect.DeepEqual(got, expected) {
+			t.Errorf("graphemes of %q: expected %q, got %q", text, expected, got)
+		}
+	}
+}
+
+func TestGraphemes_ZWJSequences(t *testing.T) {
+	tests := map[string][]string{
+		"\U0001F3F3\ufe0f\u200d\U0001F308!":          {"\U0001F3F3\ufe0f\u200d\U0001F308", "!"},      // rainbow flag
+		"\U0001F469\U0001F3FD\u200d\U0001F4BB":       {"\U0001F469\U0001F3FD\u200d\U0001F4BB"},       // technologist, with a modifier
+		"\U0001F9D1\u200d\U0001F91D\u200d\U0001F9D1": {"\U0001F9D1\u200d\U0001F91D\u200d\U0001F9D1"}, // people holding hands
+		"a\u200db":                         {"a\u200d", "b"}, // a joiner joins pictographics only
+		"\U0001F469\u200da":                {"\U0001F469\u200d", "a"},
+		"\U0001F469\u200d\u200d\U0001F4BB": {"\U0001F469\u200d\u200d", "\U0001F4BB"},
+	}
+	for text, expected := range tests {
+		if got := graphemes(text); !reflect.DeepEqual(got, expected) {
+			t.Errorf("graphemes of %+q: expected %+q, got %+q", text, expected, got)
+		}
+	}
+}
+
+func TestGraphemes_Flags(t *testing.T) {
+	tests := map[string][]string{
+		"\U0001F1E9\U0001F1F0":                                                   {"\U0001F1E9\U0001F1F0"},
+		"\U0001F1E9\U0001F1F0\U0001F1F8\U0001F1EA":                               {"\U0001F1E9\U0001F1F0", "\U0001F1F8\U0001F1EA"},
+		"\U0001F1E9\U0001F1F0\U0001F1F8":                                         {"\U0001F1E9\U0001F1F0", "\U0001F1F8"},
+		"\U0001F1E9a\U0001F1F0\U0001F1F8":                                        {"\U0001F1E9", "a", "\U0001F1F0\U0001F1F8"},                                // the pairs start anew after a letter
+		"\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F": {"\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F"}, // England, by tags
+	}
+	for text, expected := range tests {
+		if got := graphemes(text); !reflect.DeepEqual(got, expected) {
+			t.Errorf("graphemes of %+q: expected %+q, got %+q", text, expected, got)
+		}
+	}
+}
+
+func TestGraphemes_Prepend(t *testing.T) {
+	tests := map[string][]string{
+		"\u0600١٢":      {"\u0600١", "٢"},  // Arabic number sign and a digit
+		"\u0d4eക":       {"\u0d4eക"},       // Malayalam dot reph and ka
+		"\u0600\u0600١": {"\u0600\u0600١"}, // prepended characters join each other
+		"\u0600\n":      {"\u0600", "\n"},  // but not a control
+		"a\u0600":       {"a", "\u0600"},   // nor the character before them
+		"กำก":           {"กำ", "ก"},       // Thai sara am is a spacing mark
+	}
+	for text, expected := range tests {
+		if got := graphemes(text); !reflect.DeepEqual(got, expected) {
+			t.Errorf("graphemes of %+q: expected %+q, got %+q", text, expected, got)
+		}
+	}
+}
+
+// TestGraphemes_Deviations pins the documented differences from UAX #29, such that the documentation is kept true
+func TestGraphemes_Deviations(t *testing.T) {
+	tests := map[string][]string{
+		"Ⓜ\u200d\U0001F525": {"Ⓜ\u200d", "\U0001F525"}, // Ⓜ is not taken to be pictographic
+		"ｶﾞ":                {"ｶ", "ﾞ"},                // the halfwidth voiced sound mark is Lm, not Extend
+		"क्ष":               {"क्", "ष"},               // no Indic conjunct rule
+	}
+	for text, expected := range tests {
+		if got := graphemes(text); !reflect.DeepEqual(got, expected) {
+			t.Errorf("graphemes of %+q: expected %+q, got %+q", text, expected, got)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package profaneword
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Strictness limits the homoglyphs of a HomoglyphCharFormatter, each level allows the homoglyphs of the levels before it
+type Strictness uint8
+
+const (
+	// Printable allows only homoglyphs printable in common fonts: basic Cyrillic, Greek and fullwidth forms
+	Printable Strictness = iota
+	// BMP allows only single code points of the Basic Multilingual Plane, fx Cherokee, Armenian and Roman numerals
+	BMP
+	// SingleCodePoint allows only single code points, also the mathematical alphanumerics
+	SingleCodePoint
+	// AnyConfusable allows any confusable, also sequences of code points, like rn for m
+	AnyConfusable
+)
+
+var strictnessNames = [...]string{"printable", "bmp", "single", "any"}
+
+func (s Strictness) String() string {
+	if int(s) < len(strictnessNames) {
+		return strictnessNames[s]
+	}
+	return fmt.Sprintf("Strictness(%d)", s)
+}
+
+// ParseStrictness returns the Strictness of the name: printable, bmp, single or any
+func ParseStrictness(name string) (Strictness, error) {
+	for s, strictnessName := range strictnessNames {
+		if name == strictnessName {
+			return Strictness(s), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown strictness %q, expected one of printable, bmp, single or any", name)
+}
+
+// strictnessOf returns the most strict level that allows the homoglyph
+func strictnessOf(homoglyph string) Strictness {
+	r, size := utf8.DecodeRuneInString(homoglyph)
+	switch {
+	case size != len(homoglyph):
+		return AnyConfusable
+	case r > 0xFFFF:
+		return SingleCodePoint
+	case 0x0370 <= r && r <= 0x03FF, 0x0400 <= r && r <= 0x04FF, 0xFF00 <= r && r <= 0xFFEF:
+		return Printable
+	}
+	return BMP
+}
+
+// confusables, of confusables_table.go, are generated of the Unicode confusables data in testdata/confusables.txt,
+// give gen_confusables.go the full file of https://www.unicode.org/Public/security/latest/confusables.txt to update them
+//go:generate go run gen_confusables.go
+
+// mathematicalAlphanumerics are the first capital letters of the mathematical bold, sans-serif and monospace alphabets,
+// the small letters follow the capitals
+var mathematicalAlphanumerics = []rune{0x1D400, 0x1D5A0, 0x1D670}
+
+// homoglyphsOf returns the homoglyphs of the Latin letters allowed by the Strictness
+func homoglyphsOf(strictness Strictness) map[rune][]string {
+	homoglyphs := make(map[rune][]string, 52)
+	add := func(letter rune, homoglyph string) {
+		if strictnessOf(homoglyph) <= strictness {
+			homoglyphs[letter] = append(homoglyphs[letter], homoglyph)
+		}
+	}
+	for _, letters := range []struct{ first, last, fullwidth rune }{{'A', 'Z', 0xFF21}, {'a', 'z', 0xFF41}} {
+		for letter := letters.first; letter <= letters.last; letter++ {
+			for _, homoglyph := range confusables[letter] {
+				add(letter, homoglyph)
+			}
+			add(letter, string(letters.fullwidth+letter-letters.first))
+			for _, capital := range mathematicalAlphanumerics {
+				if letters.first == 'a' {
+					capital += 26
+				}
+				add(letter, string(capital+letter-letters.first))
+			}
+		}
+	}
+	return homoglyphs
+}
+
+// HomoglyphCharFormatter is a CharFormatter that replaces Latin letters by a confusable character of another script,
+// a homoglyph, chosen anew for each letter
+type HomoglyphCharFormatter struct {
+	strictness Strictness
+	homoglyphs map[rune][]string
+	rand       RandomDevice
+}
+
+var _ CharFormatter = HomoglyphCharFormatter{}
+
+// NewHomoglyphCharFormatter returns a HomoglyphCharFormatter of the homoglyphs allowed by the Strictness,
+// that chooses among them using the RandomDevice, or CryptoRand if it is nil
+func NewHomoglyphCharFormatter(strictness Strictness, device RandomDevice) HomoglyphCharFormatter {
+	if device == nil {
+		device = CryptoRand{}
+	}
+	return HomoglyphCharFormatter{strictness: strictness, homoglyphs: homoglyphsOf(strictness), rand: device}
+}
+
+// FormatRune returns a homoglyph of the rune, or the rune if it has none
+func (h HomoglyphCharFormatter) FormatRune(r rune) []rune {
+	homoglyphs := h.homoglyphs[r]
+	switch len(homoglyphs) {
+	case 0:
+		return []rune{r}
+	case 1:
+		return []rune(homoglyphs[0])
+	}
+	return []rune(homoglyphs[h.rand.RandMax(len(homoglyphs))])
+}
+
+// NewHomoglyphFormatter returns a HomoglyphCharFormatter of Printable homoglyphs, in a CharFormatterDelegatingFormatter
+func NewHomoglyphFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &CharFormatterDelegatingFormatter{NewHomoglyphCharFormatter(Printable, o.rand)}
+}
+
+// homoglyphFactory is the Factory of the homoglyph Formatter, the parameter is the Strictness
+func homoglyphFactory(param string, opts ...Option) (Formatter, error) {
+	if param == "" {
+		return NewHomoglyphFormatter(opts...), nil
+	}
+	strictness, err := ParseStrictness(param)
+	if err != nil {
+		return nil, err
+	}
+	return &CharFormatterDelegatingFormatter{NewHomoglyphCharFormatter(strictness, RandomDeviceOf(opts...))}, nil
+}
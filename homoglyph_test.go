@@ -0,0 +1,92 @@
+package profaneword
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestHomoglyphCharFormatter_strictness(t *testing.T) {
+	for s := Printable; s <= AnyConfusable; s++ {
+		parsed, err := ParseStrictness(s.String())
+		if err != nil || parsed != s {
+			t.Errorf("expected %s to parse, got %v and %v", s, parsed, err)
+		}
+		formatter := NewHomoglyphCharFormatter(s, NewSeededRand([]byte(s.String())))
+		for letter := 'A'; letter <= 'z'; letter++ {
+			if !unicode.IsLetter(letter) {
+				continue
+			}
+			got := string(formatter.FormatRune(letter))
+			if got == string(letter) || strictnessOf(got) > s {
+				t.Errorf("%s: expected a homoglyph of %q, got %q", s, letter, got)
+			}
+		}
+	}
+	if _, err := ParseStrictness("loose"); err == nil {
+		t.Errorf("expected an unknown strictness to fail")
+	}
+	if got := string(NewHomoglyphCharFormatter(Printable, nil).FormatRune('1')); got != "1" {
+		t.Errorf("expected a rune without homoglyphs to be kept, got %q", got)
+	}
+}
+
+func TestHomoglyphCharFormatter_invert(t *testing.T) {
+	for _, s := range []Strictness{Printable, SingleCodePoint} {
+		formatter := &CharFormatterDelegatingFormatter{NewHomoglyphCharFormatter(s, NewSeededRand([]byte("invert")))}
+		text := "The Quick Brown Fox Jumps Over The Lazy Dog"
+		for i := 0; i < 10; i++ {
+			got, ambiguities, err := Invert(formatter, formatter.Format(text))
+			if err != nil || got != text || len(ambiguities) != 0 {
+				t.Errorf("%s: expected %q to be inverted, got %q, %v and %v", s, text, got, ambiguities, err)
+			}
+		}
+	}
+}
+
+func TestConfusables_data(t *testing.T) {
+	data, err := os.ReadFile("testdata/confusables.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prototypes := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if version := strings.TrimPrefix(line, "# Version: "); version != line && version != confusablesVersion {
+			t.Errorf("expected the confusables of version %s, got %s", version, confusablesVersion)
+		}
+		if line, _, _ = strings.Cut(line, "#"); strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		prototypes[codePointsOf(t, fields[0])] = codePointsOf(t, fields[1])
+	}
+	for letter, homoglyphs := range confusables {
+		prototype, ok := prototypes[string(letter)]
+		if !ok {
+			prototype = string(letter)
+		}
+		for _, homoglyph := range homoglyphs {
+			if homoglyph == prototype {
+				continue // a sequence of letters, fx rn of m
+			}
+			if got, ok := prototypes[homoglyph]; !ok || got != prototype {
+				t.Errorf("expected %q to be confusable with %q of %q, got %q", homoglyph, prototype, letter, got)
+			}
+		}
+	}
+}
+
+// codePointsOf returns the text of the space separated hexadecimal code points of a field of confusables.txt
+func codePointsOf(t *testing.T, field string) string {
+	var sb strings.Builder
+	for _, hex := range strings.Fields(field) {
+		r, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sb.WriteRune(rune(r))
+	}
+	return sb.String()
+}
@@ -0,0 +1,273 @@
+package profaneword
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrNotInvertible is the error of inverting a Formatter that cannot be inverted
+var ErrNotInvertible = errors.New("cannot be inverted")
+
+// Ambiguity is a part of a formatted text that more than one text formats to
+type Ambiguity struct {
+	// Formatted is the formatted text
+	Formatted string
+	// Candidates are the texts that format to Formatted, the first is used in the inverted text
+	Candidates []string
+}
+
+// Inverter is a Formatter that can undo its formatting.
+// Invert returns the text before formatting, and the ambiguities where the inverse is not unique.
+// An Inverter that makes random decisions must be given a RandomDevice in the same state as when formatting,
+// such that it replays the decisions, fx a SeededRand of the same seed
+type Inverter interface {
+	Invert(text string) (string, []Ambiguity, error)
+}
+
+// CharInverter is a CharFormatter that can undo its formatting
+type CharInverter interface {
+	// InvertPrefix returns the number of runes, at the start of the formatted runes, that formatted a single rune,
+	// and the runes that format to them. More than one rune is an ambiguity, the first is used
+	InvertPrefix(formatted []rune) (n int, candidates []rune)
+}
+
+// Invert undoes the formatting of the Formatter, it returns an error wrapping ErrNotInvertible
+// if the Formatter, or any Formatter it wraps, is not an Inverter
+func Invert(f Formatter, text string) (string, []Ambiguity, error) {
+	inverter, ok := f.(Inverter)
+	if !ok {
+		return "", nil, fmt.Errorf("%q %w", DescriptionOf(f), ErrNotInvertible)
+	}
+	return inverter.Invert(text)
+}
+
+// Invert inverts the Formatters in reverse order
+func (m *MultiFormatter) Invert(text string) (string, []Ambiguity, error) {
+	var ambiguities []Ambiguity
+	for i := len(m.Formatters) - 1; i >= 0; i-- {
+		var found []Ambiguity
+		var err error
+		if text, found, err = Invert(m.Formatters[i], text); err != nil {
+			return "", nil, err
+		}
+		ambiguities = append(ambiguities, found...)
+	}
+	return text, ambiguities, nil
+}
+
+// Invert returns the text
+func (UnitFormatter) Invert(text string) (string, []Ambiguity, error) {
+	return text, nil, nil
+}
+
+// InvertPrefix returns the first rune
+func (UnitFormatter) InvertPrefix(formatted []rune) (int, []rune) {
+	return 1, formatted[:1]
+}
+
+// Invert inverts each word by the wrapped Formatter, the words are split the same way as when formatting
+func (p *PerWordFormattingFormatter) Invert(text string) (string, []Ambiguity, error) {
+	tokenizer := p.Tokenizer
+	if tokenizer == nil {
+		tokenizer = WhitespaceTokenizer{}
+	}
+	var ambiguities []Ambiguity
+	sb := strings.Builder{}
+	for _, token := range tokenizer.Tokenize(validUTF8(text)) {
+		if !token.Word {
+			sb.WriteString(token.Text)
+			continue
+		}
+		word, found, err := Invert(p.Other, token.Text)
+		if err != nil {
+			return "", nil, err
+		}
+		sb.WriteString(word)
+		ambiguities = append(ambiguities, found...)
+	}
+	return sb.String(), ambiguities, nil
+}
+
+// Invert replays the random decision, and inverts the text by the wrapped Formatter if it was formatted
+func (rff *RandomlyFormattingFormatter) Invert(text string) (string, []Ambiguity, error) {
+	if randAbove(rff.Rand, rff.threshold()) {
+		return Invert(rff.Other, text)
+	}
+	return text, nil, nil
+}
+
+// Invert inverts each rune by the wrapped CharFormatter, which must be a CharInverter
+func (c *CharFormatterDelegatingFormatter) Invert(text string) (string, []Ambiguity, error) {
+	var err error
+	Walk(c.CharFormatter, func(f interface{}, _ int) {
+		if _, ok := f.(CharInverter); !ok && err == nil {
+			err = fmt.Errorf("%q %w", DescriptionOf(f), ErrNotInvertible)
+		}
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	inverter := c.CharFormatter.(CharInverter)
+	var ambiguities []Ambiguity
+	formatted := []rune(text)
+	out := make([]rune, 0, len(formatted))
+	for len(formatted) > 0 {
+		n, candidates := inverter.InvertPrefix(formatted)
+		if n < 1 || len(candidates) == 0 {
+			return "", nil, fmt.Errorf("%q %w: %q", DescriptionOf(c.CharFormatter), ErrNotInvertible, string(formatted))
+		}
+		if len(candidates) > 1 {
+			ambiguity := Ambiguity{Formatted: string(formatted[:n])}
+			for _, candidate := range candidates {
+				ambiguity.Candidates = append(ambiguity.Candidates, string(candidate))
+			}
+			ambiguities = append(ambiguities, ambiguity)
+		}
+		out = append(out, candidates[0])
+		formatted = formatted[n:]
+	}
+	return string(out), ambiguities, nil
+}
+
+// InvertPrefix replays the random decision, and inverts the runes by the wrapped CharFormatter if it was formatted
+func (rff *RandomlyFormattingCharFormatter) InvertPrefix(formatted []rune) (int, []rune) {
+	if !randAbove(rff.Rand, rff.threshold()) {
+		return 1, formatted[:1]
+	}
+	if inverter, ok := rff.Other.(CharInverter); ok {
+		return inverter.InvertPrefix(formatted)
+	}
+	return 0, nil
+}
+
+// InvertPrefix switches the case of the first rune back
+func (s SwitchCaseCharFormatter) InvertPrefix(formatted []rune) (int, []rune) {
+	return 1, s.FormatRune(formatted[0])
+}
+
+// InvertPrefix returns the letters that format to the longest alternative at the start of the formatted runes,
+// in lowercase as the case is lost, or the first rune if no alternative matches.
+// An alternative that starts another, like | and |<, is split by the longest match, which may not be how it was formatted
+func (u L337CharFormatter) InvertPrefix(formatted []rune) (int, []rune) {
+	longest := 0
+	var candidates []rune
+	for letter, alternatives := range u.alphabet {
+		matched := 0
+		for _, alternative := range alternatives {
+			if replacement := []rune(alternative.Text); len(replacement) > matched && hasRunePrefix(formatted, replacement) {
+				matched = len(replacement)
+			}
+		}
+		if matched == 0 || matched < longest {
+			continue
+		}
+		if matched > longest {
+			longest, candidates = matched, candidates[:0]
+		}
+		candidates = append(candidates, unicode.ToLower(letter))
+	}
+	if longest == 0 {
+		return 1, formatted[:1]
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+	return longest, candidates
+}
+
+// InvertPrefix returns the letters of the longest homoglyph at the start of the formatted runes,
+// or the first rune if no homoglyph matches
+func (h HomoglyphCharFormatter) InvertPrefix(formatted []rune) (int, []rune) {
+	longest := 0
+	var candidates []rune
+	for letter, homoglyphs := range h.homoglyphs {
+		for _, homoglyph := range homoglyphs {
+			runes := []rune(homoglyph)
+			if len(runes) < longest || !hasRunePrefix(formatted, runes) {
+				continue
+			}
+			if len(runes) > longest {
+				longest, candidates = len(runes), candidates[:0]
+			}
+			candidates = append(candidates, letter)
+		}
+	}
+	if longest == 0 {
+		return 1, formatted[:1]
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+	return longest, candidates
+}
+
+// InvertPrefix returns the first rune and the zalgo marks that follow it, the marks are removed.
+// Marks of the text before formatting are removed too, if they are among the zalgo marks
+func (z ZalgoCharFormatter) InvertPrefix(formatted []rune) (int, []rune) {
+	n := 1
+	if !zalgoSkips(formatted[0]) {
+		for n < len(formatted) && isZalgoMark(formatted[n]) {
+			n++
+		}
+	}
+	return n, formatted[:1]
+}
+
+func hasRunePrefix(runes, prefix []rune) bool {
+	if len(prefix) > len(runes) {
+		return false
+	}
+	for i, r := range prefix {
+		if runes[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// Invert replaces the Replacement with the text the pattern matched, if the pattern is a literal text,
+// every Replacement is an ambiguity, as the text may have contained it before formatting
+func (rr *RegexReplacingFormatter) Invert(text string) (string, []Ambiguity, error) {
+	literal, complete := rr.PatternMatcher.LiteralPrefix()
+	if !complete || literal == "" || strings.Contains(rr.Replacement, "$") {
+		return "", nil, fmt.Errorf("%q %w", DescriptionOf(rr), ErrNotInvertible)
+	}
+	if literal == rr.Replacement {
+		return text, nil, nil
+	}
+	var ambiguities []Ambiguity
+	for i := strings.Count(text, rr.Replacement); i > 0; i-- {
+		ambiguities = append(ambiguities, Ambiguity{Formatted: rr.Replacement, Candidates: []string{literal, rr.Replacement}})
+	}
+	return strings.ReplaceAll(text, rr.Replacement, literal), ambiguities, nil
+}
+
+// Invert reverses the text again
+func (r ReversingFormatter) Invert(text string) (string, []Ambiguity, error) {
+	return r.Format(text), nil, nil
+}
+
+// Invert removes the emoji appended to the word, emoji that replaced words cannot be inverted
+func (e EmojiFormatter) Invert(word string) (string, []Ambiguity, error) {
+	if e.Mode == ReplaceWithEmoji {
+		return "", nil, fmt.Errorf("%q %w", DescriptionOf(e), ErrNotInvertible)
+	}
+	word = validUTF8(word)
+	start, end := letterSpan(word)
+	if emoji, ok := e.Table.Lookup(word[start:end]); ok && strings.HasPrefix(word[end:], emoji) {
+		return word[:end] + word[end+len(emoji):], nil, nil
+	}
+	return word, nil, nil
+}
+
+var _ Inverter = &MultiFormatter{}
+var _ Inverter = &PerWordFormattingFormatter{}
+var _ Inverter = &RandomlyFormattingFormatter{}
+var _ Inverter = &CharFormatterDelegatingFormatter{}
+var _ Inverter = &RegexReplacingFormatter{}
+var _ Inverter = ReversingFormatter{}
+var _ Inverter = EmojiFormatter{}
+var _ CharInverter = &RandomlyFormattingCharFormatter{}
+var _ CharInverter = L337CharFormatter{}
+var _ CharInverter = SwitchCaseCharFormatter{}
+var _ CharInverter = HomoglyphCharFormatter{}
+var _ CharInverter = ZalgoCharFormatter{}
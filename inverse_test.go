@@ -0,0 +1,111 @@
+package profaneword
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInvert_L337(t *testing.T) {
+	formatter := L337Formatter()
+	text, ambiguities, err := Invert(formatter, formatter.Format("leet speak"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "ieet speak" {
+		t.Errorf("expected the first candidate of an ambiguity to be used, got %q", text)
+	}
+	expected := []Ambiguity{{Formatted: "1", Candidates: []string{"i", "l"}}}
+	if !reflect.DeepEqual(ambiguities, expected) {
+		t.Errorf("expected %v, got %v", expected, ambiguities)
+	}
+}
+
+func TestInvert_Uber1337(t *testing.T) {
+	iOrL := []string{"i", "l"}
+	tests := []struct {
+		seed, text, formatted, inverted string
+		ambiguities                     []Ambiguity
+	}{
+		{"uber", "a quick brown fox", `/-\ ()_|_|1[|< 13120vv|\| |=()><`, "a quick brown fox", []Ambiguity{{"1", iOrL}}},
+		{"leet", "lil bill", "|1|_ !3|||_", "iil biil", []Ambiguity{{"|", iOrL}, {"1", iOrL}, {"|", iOrL}, {"|", iOrL}}},
+	}
+	for _, test := range tests {
+		formatter := Uber1337Formatter(WithRandom(NewSeededRand([]byte(test.seed))))
+		formatted := formatter.Format(test.text)
+		if formatted != test.formatted {
+			t.Fatalf("expected %q to be formatted as %q, got %q", test.text, test.formatted, formatted)
+		}
+		text, ambiguities, err := Invert(formatter, formatted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text != test.inverted {
+			t.Errorf("expected %q to be inverted to %q, got %q", formatted, test.inverted, text)
+		}
+		if !reflect.DeepEqual(ambiguities, test.ambiguities) {
+			t.Errorf("expected the ambiguities of %q to be %v, got %v", formatted, test.ambiguities, ambiguities)
+		}
+	}
+}
+
+func TestInvert_seeded(t *testing.T) {
+	tests := map[string]func(device RandomDevice) Formatter{
+		"sarcastic": func(device RandomDevice) Formatter {
+			return NewSarcasticFormatter(WithRandom(device))
+		},
+		"randomly": func(device RandomDevice) Formatter {
+			mf := &MultiFormatter{}
+			mf.With(NewRandomlyFormatter(NewWordReversingFormatter(), WithRandom(device)))
+			mf.With(&CharFormatterDelegatingFormatter{SwitchCaseCharFormatter{}})
+			return mf
+		},
+	}
+	texts := []string{"a quick brown fox jumps over the lazy dog", "né cool", ""}
+	for name, newFormatter := range tests {
+		formatter, inverse := newFormatter(NewSeededRand([]byte(name))), newFormatter(NewSeededRand([]byte(name)))
+		for _, text := range texts {
+			got, ambiguities, err := Invert(inverse, formatter.Format(text))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != text || len(ambiguities) != 0 {
+				t.Errorf("%s: expected %q to be replayed, got %q and %v", name, text, got, ambiguities)
+			}
+		}
+	}
+}
+
+func TestInvert_delimiter(t *testing.T) {
+	text, ambiguities, err := Invert(DelimiterFormatterWith("-"), "sex-fucker-you")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "sex fucker you" || len(ambiguities) != 2 {
+		t.Errorf("expected the delimiters to be ambiguous, got %q and %v", text, ambiguities)
+	}
+	if text, _, _ = Invert(DelimiterFormatterWith(" "), "a b"); text != "a b" {
+		t.Errorf("expected a space delimiter to be kept, got %q", text)
+	}
+}
+
+func TestInvert_notInvertible(t *testing.T) {
+	for _, formatter := range []Formatter{NewFatFingerFormatter(), NewShuffleFormatter(), NewUppercaseFormatter(), &MultiFormatter{[]Formatter{ReversingFormatter{}, NewStudderFormatter()}}} {
+		if _, _, err := Invert(formatter, "asd"); !errors.Is(err, ErrNotInvertible) {
+			t.Errorf("%s: expected ErrNotInvertible, got %v", DescriptionOf(formatter), err)
+		}
+	}
+}
+
+func TestInvert_alphabet(t *testing.T) {
+	formatter := &CharFormatterDelegatingFormatter{NewL337CharFormatter(Alphabet{
+		'L': uniform("1", "|_"),
+		'K': uniform("|<"),
+		'T': uniform("7", "+"),
+	}, NewSeededRand([]byte("alphabet")))}
+	for i := 0; i < 10; i++ {
+		if text, _, err := Invert(formatter, formatter.Format("kettle")); err != nil || text != "kettle" {
+			t.Errorf("expected every alternative to be inverted, got %q and %v", text, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package profaneword
+
+import (
+	"math"
+	"strings"
+)
+
+// KeyboardLayout is the layout of the keys of a keyboard, the number row, top row, home row and bottom row,
+// with the characters typed with and without shift
+type KeyboardLayout struct {
+	Name        string
+	Description string
+	neighbours  map[rune][]rune
+}
+
+// keyRow is a row of keys, the characters typed without and with shift, and the offset of the first key
+// from the left edge of the keyboard, measured in keys
+type keyRow struct {
+	keys, shifted string
+	offset        float64
+}
+
+// ansiOffsets and isoOffsets are the offsets of the rows of an ANSI and an ISO keyboard,
+// the ISO bottom row has an extra key to the left
+var (
+	ansiOffsets = [4]float64{1, 1.5, 1.75, 2.25}
+	isoOffsets  = [4]float64{1, 1.5, 1.75, 1.25}
+)
+
+// newKeyboardLayout returns the KeyboardLayout of the number row, top row, home row and bottom row,
+// typed without and with shift. Rows must have the same number of characters with and without shift,
+// offsets are the distance of the first key of each row from the left edge, in keys, the number row starts with 1.
+// It panics if a row does not match its shifted row, it is only used for the built-in KeyboardLayouts
+func newKeyboardLayout(name, description string, keys, shifted [4]string, offsets [4]float64) *KeyboardLayout {
+	var rows [4]keyRow
+	for i := range rows {
+		if len([]rune(keys[i])) != len([]rune(shifted[i])) {
+			panic("profaneword: the shifted row " + shifted[i] + " does not match " + keys[i] + " of layout " + name)
+		}
+		rows[i] = keyRow{keys[i], shifted[i], offsets[i]}
+	}
+	layout := &KeyboardLayout{Name: name, Description: description, neighbours: map[rune][]rune{}}
+	layout.addNeighbours(rows, func(row keyRow) []rune { return []rune(row.keys) })
+	layout.addNeighbours(rows, func(row keyRow) []rune { return []rune(row.shifted) })
+	return layout
+}
+
+// addNeighbours adds the keys touching each key of the layer, in the row above, the row itself and the row below,
+// the keys of adjacent rows touch when they are less than a key apart
+func (k *KeyboardLayout) addNeighbours(rows [4]keyRow, layer func(keyRow) []rune) {
+	for i, row := range rows {
+		keys := layer(row)
+		for x, r := range keys {
+			if _, seen := k.neighbours[r]; seen {
+				continue
+			}
+			var neighbours []rune
+			for j := i - 1; j <= i+1; j++ {
+				if j < 0 || j >= len(rows) {
+					continue
+				}
+				for y, other := range layer(rows[j]) {
+					distance := math.Abs(rows[j].offset + float64(y) - row.offset - float64(x))
+					if (j == i && distance == 1) || (j != i && distance < 1) {
+						neighbours = append(neighbours, other)
+					}
+				}
+			}
+			k.neighbours[r] = neighbours
+		}
+	}
+}
+
+// Neighbours returns the characters of the keys touching the key of the rune, on the same layer of shift,
+// the row above first; it returns nil if the rune is not on the layout
+func (k *KeyboardLayout) Neighbours(r rune) []rune {
+	return k.neighbours[r]
+}
+
+// KeyboardLayouts are the built-in KeyboardLayouts, the first, QWERTY, is the default
+var KeyboardLayouts = []*KeyboardLayout{
+	newKeyboardLayout("qwerty", "US QWERTY",
+		[4]string{"1234567890-=", "qwertyuiop[]", "asdfghjkl;'", "zxcvbnm,./"},
+		[4]string{"!@#$%^&*()_+", "QWERTYUIOP{}", `ASDFGHJKL:"`, "ZXCVBNM<>?"},
+		ansiOffsets),
+	newKeyboardLayout("qwertz", "German QWERTZ",
+		[4]string{"1234567890ß´", "qwertzuiopü+", "asdfghjklöä#", "<yxcvbnm,.-"},
+		[4]string{`!"§$%&/()=?` + "`", "QWERTZUIOPÜ*", "ASDFGHJKLÖÄ'", ">YXCVBNM;:_"},
+		isoOffsets),
+	newKeyboardLayout("azerty", "French AZERTY",
+		[4]string{`&é"'(-è_çà)=`, "azertyuiop^$", "qsdfghjklmù*", "<wxcvbn,;:!"},
+		[4]string{"1234567890°+", "AZERTYUIOP¨£", "QSDFGHJKLM%µ", ">WXCVBN?./§"},
+		isoOffsets),
+	newKeyboardLayout("danish", "Danish QWERTY",
+		[4]string{"1234567890+´", "qwertyuiopå¨", "asdfghjklæø'", "<zxcvbnm,.-"},
+		[4]string{`!"#¤%&/()=?` + "`", "QWERTYUIOPÅ^", "ASDFGHJKLÆØ*", ">ZXCVBNM;:_"},
+		isoOffsets),
+	newKeyboardLayout("norwegian", "Norwegian QWERTY",
+		[4]string{`1234567890+\`, "qwertyuiopå¨", "asdfghjkløæ'", "<zxcvbnm,.-"},
+		[4]string{`!"#¤%&/()=?` + "`", "QWERTYUIOPÅ^", "ASDFGHJKLØÆ*", ">ZXCVBNM;:_"},
+		isoOffsets),
+	newKeyboardLayout("dvorak", "US Dvorak",
+		[4]string{"1234567890[]", "',.pyfgcrl/=", "aoeuidhtns-", ";qjkxbmwvz"},
+		[4]string{"!@#$%^&*(){}", `"<>PYFGCRL?+`, "AOEUIDHTNS_", ":QJKXBMWVZ"},
+		ansiOffsets),
+}
+
+// LayoutNames returns the names of the KeyboardLayouts, separated by commas
+func LayoutNames() string {
+	names := make([]string, len(KeyboardLayouts))
+	for i, layout := range KeyboardLayouts {
+		names[i] = layout.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// KeyboardLayoutByName returns the KeyboardLayout of the given name from KeyboardLayouts, the name is case-insensitive
+func KeyboardLayoutByName(name string) (*KeyboardLayout, bool) {
+	for _, layout := range KeyboardLayouts {
+		if strings.EqualFold(layout.Name, name) {
+			return layout, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,48 @@
+package profaneword
+
+import (
+	"testing"
+)
+
+func TestKeyboardLayout_Neighbours(t *testing.T) {
+	tests := []struct {
+		layout     string
+		r          rune
+		neighbours string
+	}{
+		{"qwerty", 'a', "qwsz"},
+		{"qwerty", 'A', "QWSZ"},
+		{"qwerty", '5', "46rt"},
+		{"qwerty", 'z', "asx"},
+		{"qwerty", 'p', "0-o[l;"},
+		{"qwertz", 'y', "as<x"},
+		{"azerty", 'q', "azs<w"},
+		{"danish", 'æ', "pålø.-"},
+		{"norwegian", 'ø', "pålæ.-"},
+		{"norwegian", '+', `0\på`},
+		{"dvorak", 'a', "',o;"},
+		{"dvorak", 'é', ""},
+	}
+	for _, test := range tests {
+		layout, ok := KeyboardLayoutByName(test.layout)
+		if !ok {
+			t.Fatalf("expected the layout %s", test.layout)
+		}
+		if got := string(layout.Neighbours(test.r)); got != test.neighbours {
+			t.Errorf("%s: expected the neighbours of %q to be %q, got %q", test.layout, test.r, test.neighbours, got)
+		}
+	}
+}
+
+func TestFatFingerFactory(t *testing.T) {
+	f, err := fatFingerFactory("Dvorak", WithRandom(NewSeededRand([]byte("fat"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layout := f.(*CharFormatterDelegatingFormatter).CharFormatter.(FatFingerCharFormatter).Layout; layout.Name != "dvorak" {
+		t.Errorf("expected the dvorak layout, got %s", layout.Name)
+	}
+	if _, err = fatFingerFactory("colemak"); err == nil {
+		t.Errorf("expected an unknown layout to fail")
+	}
+}
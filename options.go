@@ -0,0 +1,117 @@
+package profaneword
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// Option configures a Formatter on construction
+type Option func(*options)
+
+type options struct {
+	rand      RandomDevice
+	threshold *big.Rat
+	tokenizer Tokenizer
+	layout    *KeyboardLayout
+	alphabet  Alphabet
+	emojis    func() EmojiTable
+}
+
+// WithRandom sets the RandomDevice used for the random decisions of the Formatter, the default is CryptoRand
+func WithRandom(device RandomDevice) Option {
+	return func(o *options) {
+		if device != nil {
+			o.rand = device
+		}
+	}
+}
+
+// WithThreshold sets the threshold a random number must exceed for a randomly applied Formatter to apply,
+// fx NewRandomlyFormatter and NewRandomFormatter; the default is 1/2
+func WithThreshold(threshold *big.Rat) Option {
+	return func(o *options) {
+		if threshold != nil {
+			o.threshold = threshold
+		}
+	}
+}
+
+// WithTokenizer sets the Tokenizer used by per word Formatters to split the text into words,
+// the default is WhitespaceTokenizer
+func WithTokenizer(tokenizer Tokenizer) Option {
+	return func(o *options) {
+		if tokenizer != nil {
+			o.tokenizer = tokenizer
+		}
+	}
+}
+
+// WithKeyboardLayout sets the KeyboardLayout that fat fingers type on, the default is QWERTY
+func WithKeyboardLayout(layout *KeyboardLayout) Option {
+	return func(o *options) {
+		if layout != nil {
+			o.layout = layout
+		}
+	}
+}
+
+// WithL337Alphabet sets the Alphabet of the 1337 Formatters, L337Formatter and Uber1337Formatter, in stead of their own
+func WithL337Alphabet(alphabet Alphabet) Option {
+	return func(o *options) {
+		if alphabet != nil {
+			o.alphabet = alphabet
+		}
+	}
+}
+
+// WithEmojiTable sets the EmojiTable of the emoji Formatter, in stead of the built-in table
+func WithEmojiTable(table EmojiTable) Option {
+	return func(o *options) {
+		if table != nil {
+			o.emojis = func() EmojiTable { return table }
+		}
+	}
+}
+
+// WithLazyEmojiTable sets the EmojiTable of the emoji Formatter to the table of build, in stead of the built-in table.
+// build is called once, when the first emoji Formatter of the Option is made, such that a large table is only built if used
+func WithLazyEmojiTable(build func() EmojiTable) Option {
+	var once sync.Once
+	var table EmojiTable
+	lazy := func() EmojiTable {
+		once.Do(func() { table = build() })
+		return table
+	}
+	return func(o *options) {
+		if build != nil {
+			o.emojis = lazy
+		}
+	}
+}
+
+// ParseProbability parses a probability, a number between 0 and 1 given as a decimal or a fraction, fx 0.2 or 1/10
+func ParseProbability(text string) (*big.Rat, error) {
+	p, ok := new(big.Rat).SetString(text)
+	if !ok || p.Sign() < 0 || p.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, fmt.Errorf("invalid probability %q: must be a number between 0 and 1, fx 0.2 or 1/10", text)
+	}
+	return p, nil
+}
+
+func newOptions(opts []Option) options {
+	o := options{rand: CryptoRand{}, threshold: big.NewRat(1, 2), tokenizer: WhitespaceTokenizer{}, layout: KeyboardLayouts[0]}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o options) thresholdRandom() thresholdRandom {
+	return newRandomFormatter(o.rand, o.threshold)
+}
+
+// RandomDeviceOf returns the RandomDevice set by the given options, the default is CryptoRand
+func RandomDeviceOf(opts ...Option) RandomDevice {
+	return newOptions(opts).rand
+}
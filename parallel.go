@@ -0,0 +1,138 @@
+package profaneword
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+)
+
+// batchSize is the least number of bytes of whole lines a parallel writer gives a worker at a time
+const batchSize = 32 * 1024
+
+// parallelWriter formats batches of lines with a pool of workers, and writes the results in order
+type parallelWriter struct {
+	w   io.Writer
+	buf []byte
+	// in and out are the batches to, and the formatted batches from, each worker
+	in   []chan []byte
+	out  []chan []byte
+	next int
+	done chan struct{}
+
+	mu     sync.Mutex
+	err    error
+	closed bool
+}
+
+// NewParallelWriter returns an io.WriteCloser that formats the text written to it like NewWriter,
+// but formats batches of lines concurrently by jobs workers.
+// newFormatter is called once for each worker, with the index of the worker, such that each worker
+// has its own Formatter, and RandomDevice, and does not share state with the other workers.
+// The batches are given to the workers in turn, and written to w in the order they were written,
+// thus the output of seeded RandomDevices is the same for the same text and number of jobs.
+// Close must be called to format the remaining text and stop the workers, it does not close w
+func NewParallelWriter(w io.Writer, newFormatter func(worker int) Formatter, jobs int) io.WriteCloser {
+	if jobs < 1 {
+		jobs = 1
+	}
+	pw := &parallelWriter{
+		w:    w,
+		in:   make([]chan []byte, jobs),
+		out:  make([]chan []byte, jobs),
+		done: make(chan struct{}),
+	}
+	for worker := range pw.in {
+		pw.in[worker] = make(chan []byte, 1)
+		pw.out[worker] = make(chan []byte, 1)
+		go formatBatches(newFormatter(worker), pw.in[worker], pw.out[worker])
+	}
+	go pw.collect()
+	return pw
+}
+
+// formatBatches formats each batch of the in channel to the out channel, it closes out when in is closed
+func formatBatches(f Formatter, in <-chan []byte, out chan<- []byte) {
+	for batch := range in {
+		formatted, consumed := appendFormattedLines(make([]byte, 0, len(batch)), batch, f)
+		if consumed < len(batch) {
+			formatted = append(formatted, f.Format(string(batch[consumed:]))...)
+		}
+		out <- formatted
+	}
+	close(out)
+}
+
+// collect writes the formatted batches in the order they were given to the workers.
+// A worker only closes its out channel after its last batch, thus the first closed channel ends the output
+func (pw *parallelWriter) collect() {
+	defer close(pw.done)
+	for worker := 0; ; worker = (worker + 1) % len(pw.out) {
+		formatted, ok := <-pw.out[worker]
+		if !ok {
+			return
+		}
+		if pw.error() != nil {
+			continue // drain the workers
+		}
+		if _, err := pw.w.Write(formatted); err != nil {
+			pw.mu.Lock()
+			pw.err = err
+			pw.mu.Unlock()
+		}
+	}
+}
+
+func (pw *parallelWriter) error() error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	return pw.err
+}
+
+// Write buffers p, and gives the workers a batch whenever batchSize bytes of whole lines are buffered
+func (pw *parallelWriter) Write(p []byte) (int, error) {
+	if pw.closed {
+		return 0, errors.New("profaneword: write to closed parallel writer")
+	}
+	if err := pw.error(); err != nil {
+		return 0, err
+	}
+	pw.buf = append(pw.buf, p...)
+	for len(pw.buf) >= batchSize {
+		cut := bytes.IndexByte(pw.buf[batchSize-1:], '\n') + batchSize
+		if cut < batchSize {
+			if len(pw.buf) <= maxBuffered {
+				break
+			}
+			if cut = partCut(pw.buf[:maxBuffered]); cut == 0 {
+				cut = maxBuffered
+			}
+		}
+		pw.dispatch(pw.buf[:cut:cut])
+		pw.buf = append([]byte(nil), pw.buf[cut:]...)
+	}
+	return len(p), nil
+}
+
+// dispatch gives the batch to the next worker
+func (pw *parallelWriter) dispatch(batch []byte) {
+	pw.in[pw.next] <- batch
+	pw.next = (pw.next + 1) % len(pw.in)
+}
+
+// Close gives the remaining text to the workers, and waits for all of it to be formatted and written
+func (pw *parallelWriter) Close() error {
+	if pw.closed {
+		return pw.error()
+	}
+	pw.closed = true
+	if len(pw.buf) > 0 {
+		pw.dispatch(pw.buf)
+		pw.buf = nil
+	}
+	for _, in := range pw.in {
+		close(in)
+	}
+	<-pw.done
+	return pw.error()
+}
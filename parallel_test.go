@@ -0,0 +1,108 @@
+package profaneword
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func writeParallel(t *testing.T, text string, chunk, jobs int, newFormatter func(int) Formatter) string {
+	out := bytes.Buffer{}
+	w := NewParallelWriter(&out, newFormatter, jobs)
+	for i := 0; i < len(text); i += chunk {
+		end := i + chunk
+		if end > len(text) {
+			end = len(text)
+		}
+		if _, err := w.Write([]byte(text[i:end])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func TestNewParallelWriter(t *testing.T) {
+	lines := strings.Builder{}
+	for i := 0; lines.Len() < 5*batchSize; i++ {
+		fmt.Fprintf(&lines, "line %d of the text\r\n", i)
+	}
+	texts := []string{
+		"",
+		"one\ntwo",
+		lines.String(),
+		lines.String() + "no newline",
+	}
+	for _, text := range texts {
+		expected := &bytes.Buffer{}
+		w := NewWriter(expected, ReversingFormatter{})
+		_, _ = w.Write([]byte(text))
+		_ = w.Close()
+		for _, jobs := range []int{0, 1, 3, 8} {
+			for _, chunk := range []int{7, 4096, len(text) + 1} {
+				got := writeParallel(t, text, chunk, jobs, func(int) Formatter { return ReversingFormatter{} })
+				if got != expected.String() {
+					t.Errorf("%d jobs, writing %d bytes at a time: the output differs from NewWriter", jobs, chunk)
+				}
+			}
+		}
+	}
+}
+
+func TestNewParallelWriter_longLine(t *testing.T) {
+	text := strings.Repeat("word ", maxBuffered) + "\n" + strings.Repeat("ø", maxBuffered)
+	got := writeParallel(t, text, 1000, 4, func(int) Formatter { return ReversingFormatter{} })
+	if len(got) != len(text) || got[len(got)-1] == '\n' {
+		t.Errorf("expected the long lines to be kept, got %d bytes of %d", len(got), len(text))
+	}
+}
+
+func TestNewParallelWriter_seeded(t *testing.T) {
+	text := strings.Repeat("a quick brown fox jumps over the lazy dog\n", 4096)
+	newFormatter := func(worker int) Formatter {
+		device := NewSeededRand([]byte(fmt.Sprintf("seed %d", worker)))
+		return NewSarcasticFormatter(WithRandom(device))
+	}
+	first := writeParallel(t, text, 1000, 4, newFormatter)
+	if second := writeParallel(t, text, 333, 4, newFormatter); first != second {
+		t.Errorf("expected the same output for the same seeds and number of jobs")
+	}
+	if !strings.EqualFold(first, text) {
+		t.Errorf("expected the text to only change case")
+	}
+}
+
+func TestNewParallelWriter_error(t *testing.T) {
+	w := NewParallelWriter(errWriter{}, func(int) Formatter { return UnitFormatter{} }, 2)
+	for i := 0; i < 10; i++ {
+		_, _ = w.Write(bytes.Repeat([]byte("line\n"), batchSize))
+	}
+	if err := w.Close(); err != io.ErrShortWrite {
+		t.Errorf("expected the error of the underlying writer, got %v", err)
+	}
+}
+
+func BenchmarkNewParallelWriter(b *testing.B) {
+	text := []byte(obscureInput)
+	newFormatter := func(int) Formatter {
+		device := NewLocalCryptoRand()
+		mf := &MultiFormatter{}
+		mf.With(NewSarcasticFormatter(WithRandom(device)))
+		mf.With(NewFatFingerFormatter(WithRandom(device)))
+		return mf
+	}
+	for _, jobs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			b.SetBytes(int64(len(text)))
+			for i := 0; i < b.N; i++ {
+				w := NewParallelWriter(io.Discard, newFormatter, jobs)
+				_, _ = w.Write(text)
+				_ = w.Close()
+			}
+		})
+	}
+}
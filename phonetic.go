@@ -0,0 +1,234 @@
+package profaneword
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// natoAlphabet is the NATO phonetic alphabet, of the letters a to z
+var natoAlphabet = [26]string{
+	"alfa", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliett", "kilo", "lima", "mike",
+	"november", "oscar", "papa", "quebec", "romeo", "sierra", "tango", "uniform", "victor", "whiskey", "x-ray", "yankee", "zulu",
+}
+
+var natoDigits = [10]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+// symbolNames are the spoken names of symbols
+var symbolNames = map[rune]string{
+	' ': "space", '\t': "tab", '!': "exclamation mark", '"': "double quote", '#': "hash", '$': "dollar", '%': "percent",
+	'&': "ampersand", '\'': "apostrophe", '(': "open parenthesis", ')': "close parenthesis", '*': "asterisk", '+': "plus",
+	',': "comma", '-': "hyphen", '.': "period", '/': "slash", ':': "colon", ';': "semicolon", '<': "less than",
+	'=': "equals", '>': "greater than", '?': "question mark", '@': "at sign", '[': "open bracket", '\\': "backslash",
+	']': "close bracket", '^': "caret", '_': "underscore", '`': "backtick", '{': "open brace", '|': "vertical bar",
+	'}': "close brace", '~': "tilde", '§': "section sign", '¤': "currency sign", '£': "pound sign", '€': "euro sign",
+	'°': "degree sign", '´': "acute accent", '¨': "diaeresis", 'µ': "micro sign",
+}
+
+// NATOFormatter is a Formatter that spells out the text in the NATO phonetic alphabet, such that it can be read aloud:
+// capital letters are spelled "capital", digits and symbols by their name, other characters by their code point
+type NATOFormatter struct{}
+
+var _ Formatter = NATOFormatter{}
+
+// Format returns the spelling of each character of the text, separated by commas
+func (NATOFormatter) Format(text string) string {
+	var spelled []string
+	for _, r := range validUTF8(text) {
+		spelled = append(spelled, natoOf(r))
+	}
+	return strings.Join(spelled, ", ")
+}
+
+// natoOf returns the spelling of a single rune
+func natoOf(r rune) string {
+	switch {
+	case 'a' <= r && r <= 'z':
+		return natoAlphabet[r-'a']
+	case 'A' <= r && r <= 'Z':
+		return "capital " + natoAlphabet[r-'A']
+	case '0' <= r && r <= '9':
+		return natoDigits[r-'0']
+	}
+	if name, ok := symbolNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("%U", r)
+}
+
+// NewNATOFormatter returns a NATOFormatter
+func NewNATOFormatter(...Option) Formatter {
+	return NATOFormatter{}
+}
+
+// morseCode is the International Morse code of letters, digits and punctuation
+var morseCode = map[rune]string{
+	'A': ".-", 'B': "-...", 'C': "-.-.", 'D': "-..", 'E': ".", 'F': "..-.", 'G': "--.", 'H': "....", 'I': "..",
+	'J': ".---", 'K': "-.-", 'L': ".-..", 'M': "--", 'N': "-.", 'O': "---", 'P': ".--.", 'Q': "--.-", 'R': ".-.",
+	'S': "...", 'T': "-", 'U': "..-", 'V': "...-", 'W': ".--", 'X': "-..-", 'Y': "-.--", 'Z': "--..",
+	'0': "-----", '1': ".----", '2': "..---", '3': "...--", '4': "....-", '5': ".....", '6': "-....", '7': "--...",
+	'8': "---..", '9': "----.",
+	'.': ".-.-.-", ',': "--..--", '?': "..--..", '\'': ".----.", '!': "-.-.--", '/': "-..-.", '(': "-.--.",
+	')': "-.--.-", '&': ".-...", ':': "---...", ';': "-.-.-.", '=': "-...-", '+': ".-.-.", '-': "-....-",
+	'_': "..--.-", '"': ".-..-.", '$': "...-..-", '@': ".--.-.",
+}
+
+// MorseFormatter is a Formatter that writes the text in International Morse code,
+// the characters are separated by a space and the words by a slash, characters without a code are kept as they are
+type MorseFormatter struct{}
+
+var _ Formatter = MorseFormatter{}
+
+// Format returns the Morse code of the text, case is lost
+func (MorseFormatter) Format(text string) string {
+	var words []string
+	for _, word := range strings.Fields(validUTF8(text)) {
+		var codes []string
+		for _, r := range word {
+			if code, ok := morseCode[unicode.ToUpper(r)]; ok {
+				codes = append(codes, code)
+			} else {
+				codes = append(codes, string(r))
+			}
+		}
+		words = append(words, strings.Join(codes, " "))
+	}
+	return strings.Join(words, " / ")
+}
+
+// NewMorseFormatter returns a MorseFormatter
+func NewMorseFormatter(...Option) Formatter {
+	return MorseFormatter{}
+}
+
+// brailleLetters are the Unicode Braille patterns of the letters a to z
+const brailleLetters = "⠁⠃⠉⠙⠑⠋⠛⠓⠊⠚⠅⠇⠍⠝⠕⠏⠟⠗⠎⠞⠥⠧⠺⠭⠽⠵"
+
+// braillePunctuation are the Unicode Braille patterns of punctuation
+var braillePunctuation = map[rune]rune{
+	',': '⠂', ';': '⠆', ':': '⠒', '.': '⠲', '!': '⠖', '?': '⠦', '\'': '⠄', '-': '⠤', '(': '⠶', ')': '⠶', '/': '⠌',
+}
+
+const (
+	brailleCapital = '⠠'
+	brailleNumber  = '⠼'
+	brailleLetter  = '⠰'
+)
+
+// BrailleFormatter is a Formatter that writes the text in uncontracted (grade 1) English Braille, using Unicode Braille patterns.
+// Capitals are marked by the capital sign, numbers by the number sign, characters without a pattern are kept as they are
+type BrailleFormatter struct{}
+
+var _ Formatter = BrailleFormatter{}
+
+// Format returns the Braille of the text
+func (BrailleFormatter) Format(text string) string {
+	letters := []rune(brailleLetters)
+	sb := strings.Builder{}
+	number := false
+	for _, r := range validUTF8(text) {
+		lower := unicode.ToLower(r)
+		switch {
+		case '0' <= r && r <= '9':
+			if !number {
+				sb.WriteRune(brailleNumber)
+				number = true
+			}
+			sb.WriteRune(letters[(r-'0'+9)%10])
+			continue
+		case 'a' <= lower && lower <= 'z':
+			if number && lower <= 'j' {
+				sb.WriteRune(brailleLetter)
+			}
+			if r != lower {
+				sb.WriteRune(brailleCapital)
+			}
+			sb.WriteRune(letters[lower-'a'])
+		case braillePunctuation[r] != 0:
+			sb.WriteRune(braillePunctuation[r])
+		default:
+			sb.WriteRune(r)
+		}
+		number = false
+	}
+	return sb.String()
+}
+
+// NewBrailleFormatter returns a BrailleFormatter
+func NewBrailleFormatter(...Option) Formatter {
+	return BrailleFormatter{}
+}
+
+// PigLatinFormatter is a Formatter that translates a word to Pig Latin: the consonants before the first vowel are moved
+// to the end of the word followed by "ay", a word starting with a vowel gets "way".
+// Leading and trailing characters that are not letters are kept in place, as is the case of the first letter
+type PigLatinFormatter struct{}
+
+var _ Formatter = PigLatinFormatter{}
+
+// Format returns the Pig Latin of the word
+func (PigLatinFormatter) Format(word string) string {
+	word = validUTF8(word)
+	start, end := letterSpan(word)
+	if start == end {
+		return word
+	}
+	letters := []rune(word[start:end])
+	split := 0
+	for split < len(letters) && !isPigLatinVowel(letters, split) {
+		split++
+	}
+	if split < len(letters) && split > 0 && unicode.ToLower(letters[split-1]) == 'q' && unicode.ToLower(letters[split]) == 'u' {
+		split++
+	}
+	suffix := "ay"
+	if split == 0 {
+		suffix = "way"
+	}
+	if allUpper(letters) {
+		suffix = strings.ToUpper(suffix)
+	}
+	translated := append(append([]rune{}, letters[split:]...), letters[:split]...)
+	if split > 0 && split < len(letters) && unicode.IsUpper(letters[0]) && !allUpper(letters) {
+		translated[0] = unicode.ToUpper(translated[0])
+		translated[len(letters)-split] = unicode.ToLower(translated[len(letters)-split])
+	}
+	return word[:start] + string(translated) + suffix + word[end:]
+}
+
+// isPigLatinVowel reports whether the letter at i is a vowel, y is a vowel unless it is the first letter
+func isPigLatinVowel(letters []rune, i int) bool {
+	switch unicode.ToLower(letters[i]) {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	case 'y':
+		return i > 0
+	}
+	return false
+}
+
+// letterSpan returns the start and end of the letters of the word, without the characters before and after them
+func letterSpan(word string) (start, end int) {
+	start = strings.IndexFunc(word, unicode.IsLetter)
+	if start == -1 {
+		return 0, 0
+	}
+	end = strings.LastIndexFunc(word, unicode.IsLetter)
+	_, size := utf8.DecodeRuneInString(word[end:])
+	return start, end + size
+}
+
+func allUpper(letters []rune) bool {
+	for _, r := range letters {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return len(letters) > 1
+}
+
+// NewPigLatinFormatter returns a PigLatinFormatter per word, splitting words by the Tokenizer given by WithTokenizer
+func NewPigLatinFormatter(opts ...Option) Formatter {
+	return NewPerWordFormatter(PigLatinFormatter{}, opts...)
+}
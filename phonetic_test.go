@@ -0,0 +1,102 @@
+package profaneword
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNATOFormatter(t *testing.T) {
+	for r := 'a'; r <= 'z'; r++ {
+		if got := (NATOFormatter{}).Format(string(r)); got != natoAlphabet[r-'a'] || got[0] != byte(r) {
+			t.Errorf("expected %q to be spelled by its own code word, got %q", r, got)
+		}
+		upper := r - 'a' + 'A'
+		if got := (NATOFormatter{}).Format(string(upper)); got != "capital "+natoAlphabet[r-'a'] {
+			t.Errorf("expected %q to be spelled as a capital, got %q", upper, got)
+		}
+	}
+	text := "Hi 8===D!é"
+	got := (NATOFormatter{}).Format(text)
+	if spelled := strings.Split(got, ", "); len(spelled) != utf8.RuneCountInString(text) {
+		t.Errorf("expected each character to be spelled on its own, got %q", got)
+	}
+	if expected := "capital hotel, india, space, eight, equals, equals, equals, capital delta, exclamation mark, U+00E9"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestMorseFormatter(t *testing.T) {
+	letters := make(map[string]rune, len(morseCode))
+	for r, code := range morseCode {
+		if other, dup := letters[code]; dup {
+			t.Errorf("expected %q and %q to have different codes, both are %s", r, other, code)
+		}
+		letters[code] = r
+	}
+	got := (MorseFormatter{}).Format("SOS,  help\tnée")
+	if expected := "... --- ... --..-- / .... . .-.. .--. / -. é ."; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	var decoded []string
+	for _, word := range strings.Split(got, " / ") {
+		var sb strings.Builder
+		for _, code := range strings.Split(word, " ") {
+			if r, ok := letters[code]; ok {
+				sb.WriteRune(r)
+			} else {
+				sb.WriteString(code)
+			}
+		}
+		decoded = append(decoded, sb.String())
+	}
+	if text := strings.Join(decoded, " "); text != "SOS, HELP NéE" {
+		t.Errorf("expected the code to decode to the text in uppercase, with the whitespace of a single space, got %q", text)
+	}
+}
+
+func TestBrailleFormatter(t *testing.T) {
+	tests := map[string]string{
+		"abc":    "⠁⠃⠉",
+		"Hi":     "⠠⠓⠊",
+		"2023":   "⠼⠃⠚⠃⠉",
+		"42b":    "⠼⠙⠃⠰⠃", // b after a number reads as a digit without the letter sign
+		"1z":     "⠼⠁⠵",   // z is not a digit, it needs no letter sign
+		"1 2":    "⠼⠁ ⠼⠃", // each number has the number sign
+		"wow!?":  "⠺⠕⠺⠖⠦",
+		"(x-ø)":  "⠶⠭⠤ø⠶",
+		"A1.":    "⠠⠁⠼⠁⠲",
+		"\xff":   "�",
+		"":       "",
+		"Zz, ok": "⠠⠵⠵⠂ ⠕⠅",
+	}
+	for text, expected := range tests {
+		if got := (BrailleFormatter{}).Format(text); got != expected {
+			t.Errorf("expected %q to be %q, got %q", text, expected, got)
+		}
+	}
+}
+
+func TestPigLatinFormatter(t *testing.T) {
+	words := map[string]string{
+		"pig":      "igpay",
+		"apple":    "appleway",
+		"quick":    "ickquay",
+		"squeal":   "ealsquay",
+		"rhythm":   "ythmrhay",
+		"yellow":   "ellowyay",
+		"Hello":    "Ellohay",
+		"RHYTHM":   "YTHMRHAY",
+		"(string)": "(ingstray)",
+		"42":       "42",
+		"nth":      "nthay",
+	}
+	for word, expected := range words {
+		if got := (PigLatinFormatter{}).Format(word); got != expected {
+			t.Errorf("expected %q to be %q, got %q", word, expected, got)
+		}
+	}
+	if got := NewPigLatinFormatter(WithTokenizer(WordTokenizer{})).Format("pig-latin, ok"); got != "igpay-atinlay, okway" {
+		t.Errorf("expected each word given by the tokenizer to be translated, got %q", got)
+	}
+}
@@ -0,0 +1,256 @@
+package profaneword
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CharClass is a bitmask of classes of characters, that a Policy may require
+type CharClass uint8
+
+const (
+	// Upper is uppercase letters
+	Upper CharClass = 1 << iota
+	// Lower is lowercase letters
+	Lower
+	// Digit is the digits 0-9
+	Digit
+	// Symbol is any printable character that is not a letter, a digit or a space
+	Symbol
+	// Letter is any letter, of any case; it overlaps Upper and Lower
+	Letter
+)
+
+// countedClasses are the distinct classes counted by Policy.MinClasses
+const countedClasses = Upper | Lower | Digit | Symbol
+
+var charClassNames = [...]string{"upper", "lower", "digit", "symbol", "letter"}
+
+// String returns the names of the CharClass in the bitmask, comma separated
+func (c CharClass) String() string {
+	var names []string
+	for i, name := range charClassNames {
+		if c&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// ParseCharClasses parses a comma separated list of CharClass names, fx "upper,digit,symbol"
+func ParseCharClasses(text string) (CharClass, error) {
+	var classes CharClass
+	for _, name := range strings.Split(text, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for i, className := range charClassNames {
+			if strings.EqualFold(name, className) {
+				classes |= 1 << i
+				found = true
+			}
+		}
+		if !found {
+			return classes, fmt.Errorf("unknown character class: %s", name)
+		}
+	}
+	return classes, nil
+}
+
+func isSymbol(r rune) bool {
+	return unicode.IsPrint(r) && !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+}
+
+// classOf returns the CharClass of a single rune, or 0
+func classOf(r rune) CharClass {
+	switch {
+	case unicode.IsUpper(r):
+		return Upper
+	case unicode.IsLower(r):
+		return Lower
+	case unicode.IsDigit(r):
+		return Digit
+	case isSymbol(r):
+		return Symbol
+	}
+	return 0
+}
+
+// classesOf returns all the CharClass's present in the text, Letter included
+func classesOf(text string) (classes CharClass) {
+	for _, r := range text {
+		classes |= classOf(r)
+	}
+	if classes&(Upper|Lower) != 0 || strings.IndexFunc(text, unicode.IsLetter) != -1 {
+		classes |= Letter
+	}
+	return
+}
+
+// countOfClasses returns the number of distinct countedClasses in the CharClass
+func countOfClasses(classes CharClass) int {
+	return bits.OnesCount8(uint8(classes & countedClasses))
+}
+
+// Policy is a password policy, of the kind most systems have for their passwords
+type Policy struct {
+	// MinLength is the minimum number of characters
+	MinLength int
+	// MaxLength is the maximum number of characters, 0 is unlimited
+	MaxLength int
+	// Require is the classes of characters that must be present
+	Require CharClass
+	// MinClasses is the minimum number of distinct classes of upper, lower, digit and symbol that must be present
+	MinClasses int
+	// ForbiddenChars are the characters that must not be present
+	ForbiddenChars string
+	// AllowedChars are the only characters that may be present, any character is allowed if it is empty
+	AllowedChars string
+}
+
+// Allows reports whether the rune is allowed by the Policy
+func (p Policy) Allows(r rune) bool {
+	return (p.AllowedChars == "" || strings.ContainsRune(p.AllowedChars, r)) && !strings.ContainsRune(p.ForbiddenChars, r)
+}
+
+func (p Policy) allowsAll(runes []rune) bool {
+	for _, r := range runes {
+		if !p.Allows(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Delimiters returns the candidate delimiters that are allowed by the Policy
+func (p Policy) Delimiters(candidates string) string {
+	var allowed []rune
+	for _, r := range candidates {
+		if p.Allows(r) {
+			allowed = append(allowed, r)
+		}
+	}
+	return string(allowed)
+}
+
+// Check returns an error describing the first violation of the Policy, or nil if the password complies
+func (p Policy) Check(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return fmt.Errorf("password is shorter than %d characters", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return fmt.Errorf("password is longer than %d characters", p.MaxLength)
+	}
+	if idx := strings.IndexFunc(password, func(r rune) bool { return !p.Allows(r) }); idx != -1 {
+		r, _ := utf8.DecodeRuneInString(password[idx:])
+		return fmt.Errorf("password contains the forbidden character %q", r)
+	}
+	classes := classesOf(password)
+	if missing := p.Require &^ classes; missing != 0 {
+		return fmt.Errorf("password is missing characters of class: %s", missing)
+	}
+	if n := countOfClasses(classes); n < p.MinClasses {
+		return fmt.Errorf("password has characters of %d classes, at least %d of %s are required", n, p.MinClasses, countedClasses)
+	}
+	return nil
+}
+
+// symbolL337Map is the subset of uber1337Alphabet where the replacements are only symbols
+var symbolL337Map = func() map[rune][][]rune {
+	symbols := make(map[rune][][]rune)
+	for k, alternatives := range uber1337Alphabet {
+		for _, alt := range alternatives {
+			if classesOf(alt.Text) == Symbol {
+				symbols[k] = append(symbols[k], []rune(alt.Text))
+			}
+		}
+	}
+	return symbols
+}()
+
+// replacements returns the formatted alternatives for a rune, using the given CharFormatter
+func replacements(formatter CharFormatter) func(rune) [][]rune {
+	return func(r rune) [][]rune {
+		return [][]rune{formatter.FormatRune(r)}
+	}
+}
+
+// enforcers are the alternative replacements of a rune, used to add a missing CharClass
+var enforcers = []struct {
+	class        CharClass
+	replacements func(rune) [][]rune
+}{
+	{Upper, replacements(UppercaseCharFormatter{})},
+	{Lower, replacements(LowercaseCharFormatter{})},
+	{Digit, replacements(NewL337CharFormatter(l337Alphabet, nil))},
+	{Symbol, func(r rune) [][]rune {
+		return symbolL337Map[unicode.ToUpper(r)]
+	}},
+}
+
+// Enforce adds the required classes of characters missing from the password, and the classes missing from MinClasses
+// in the order upper, lower, digit and symbol, by formatting a single randomly chosen letter for each missing class:
+// uppercase and lowercase as SCREAM and whisper, digits as 1337 and symbols using the uber1337 alphabet.
+// Replacements with forbidden characters are never made, neither is the last letter of a class that counts replaced.
+// Enforce cannot fix the length of a password, nor characters that are forbidden, always Check the result.
+func (p Policy) Enforce(password string, opts ...Option) string {
+	o := newOptions(opts)
+	runes := []rune(password)
+	for _, enforcer := range enforcers {
+		present := classesOf(string(runes))
+		if present&enforcer.class != 0 || p.Require&enforcer.class == 0 && countOfClasses(present) >= p.MinClasses {
+			continue
+		}
+		var candidates []int
+		for i, r := range runes {
+			if class := classOf(r); class&(Upper|Lower) == 0 || p.keeps(class) && countOf(runes, class) == 1 ||
+				p.Require&Letter != 0 && countOf(runes, Upper)+countOf(runes, Lower) == 1 {
+				continue
+			}
+			if len(p.allowedReplacements(r, enforcer.class, enforcer.replacements)) > 0 {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		idx := candidates[o.rand.RandMax(len(candidates))]
+		alternatives := p.allowedReplacements(runes[idx], enforcer.class, enforcer.replacements)
+		replaced := append([]rune{}, runes[:idx]...)
+		replaced = append(replaced, alternatives[o.rand.RandMax(len(alternatives))]...)
+		runes = append(replaced, runes[idx+1:]...)
+	}
+	return string(runes)
+}
+
+// keeps reports whether the last character of the CharClass must be kept, as it is required or counts towards MinClasses
+func (p Policy) keeps(class CharClass) bool {
+	return p.Require&class != 0 || p.MinClasses > 0
+}
+
+// allowedReplacements returns the replacements of the rune that are of the CharClass, and allowed by the Policy
+func (p Policy) allowedReplacements(r rune, class CharClass, replacements func(rune) [][]rune) [][]rune {
+	var allowed [][]rune
+	for _, replacement := range replacements(r) {
+		if classesOf(string(replacement))&class != 0 && p.allowsAll(replacement) {
+			allowed = append(allowed, replacement)
+		}
+	}
+	return allowed
+}
+
+// countOf counts the runes of the given CharClass
+func countOf(runes []rune, class CharClass) (count int) {
+	for _, r := range runes {
+		if classOf(r) == class {
+			count++
+		}
+	}
+	return
+}
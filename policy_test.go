@@ -0,0 +1,100 @@
+package profaneword
+
+import (
+	"testing"
+)
+
+func TestParseCharClasses(t *testing.T) {
+	classes, err := ParseCharClasses("upper, digit,SYMBOL")
+	if err != nil || classes != Upper|Digit|Symbol {
+		t.Errorf("unexpected classes: %s, %v", classes, err)
+	}
+	if _, err = ParseCharClasses("upper,vowel"); err == nil {
+		t.Errorf("expected an error on an unknown class")
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	p := Policy{MinLength: 4, MaxLength: 8, Require: Upper | Digit, ForbiddenChars: `"\ `}
+	tests := map[string]bool{
+		"Ab1":       false,
+		"Abcdefgh1": false,
+		"Ab 1":      false,
+		"abc1":      false,
+		"Abcd":      false,
+		"Abc1!":     true,
+	}
+	for password, compliant := range tests {
+		if err := p.Check(password); (err == nil) != compliant {
+			t.Errorf("unexpected compliance of %q: %v", password, err)
+		}
+	}
+}
+
+func TestPolicy_Delimiters(t *testing.T) {
+	p := Policy{ForbiddenChars: `'"\`}
+	if got := p.Delimiters(`.'"\-`); got != ".-" {
+		t.Errorf("expected forbidden delimiters to be removed, got %s", got)
+	}
+}
+
+func TestPolicy_Enforce(t *testing.T) {
+	p := Policy{Require: Upper | Lower | Digit | Symbol, ForbiddenChars: "@"}
+	for i := 0; i < 100; i++ {
+		got := p.Enforce("abracadabra", WithRandom(CryptoRand{}))
+		if err := p.Check(got); err != nil {
+			t.Fatalf("expected an enforced password to comply, got %s: %v", got, err)
+		}
+	}
+}
+
+func TestPolicy_Enforce_Impossible(t *testing.T) {
+	p := Policy{Require: Digit}
+	if got := p.Enforce("xxx"); got != "xxx" {
+		t.Errorf("expected no replacement when no letter can be a digit, got %s", got)
+	}
+}
+
+func TestPolicy_Check_MinClasses(t *testing.T) {
+	p := Policy{MinClasses: 3}
+	tests := map[string]bool{
+		"abcdef": false,
+		"abc123": false,
+		"Abc123": true,
+		"abc12!": true,
+		"ABC!!!": false,
+	}
+	for password, compliant := range tests {
+		if err := p.Check(password); (err == nil) != compliant {
+			t.Errorf("unexpected compliance of %q: %v", password, err)
+		}
+	}
+}
+
+func TestPolicy_Check_Letter(t *testing.T) {
+	p := Policy{Require: Letter | Digit}
+	tests := map[string]bool{
+		"123456": false,
+		"abc123": true,
+		"ABC123": true,
+		"æøå123": true,
+	}
+	for password, compliant := range tests {
+		if err := p.Check(password); (err == nil) != compliant {
+			t.Errorf("unexpected compliance of %q: %v", password, err)
+		}
+	}
+}
+
+func TestPolicy_Enforce_MinClasses(t *testing.T) {
+	p := Policy{MinClasses: 3}
+	for i := 0; i < 100; i++ {
+		got := p.Enforce("abracadabra", WithRandom(CryptoRand{}))
+		if err := p.Check(got); err != nil {
+			t.Fatalf("expected an enforced password to comply, got %s: %v", got, err)
+		}
+		if classes := classesOf(got); classes&countedClasses != Upper|Lower|Digit {
+			t.Fatalf("expected the classes to be added in order, got %s of %s", classes, got)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package profaneword
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Preset is a named Policy, encoding the password rules of a common system
+type Preset struct {
+	Name        string
+	Description string
+	Policy      Policy
+}
+
+// awsIAMSymbols are the non-alphanumeric characters allowed by the AWS IAM password policy
+const awsIAMSymbols = `!@#$%^&*()_+-=[]{}|'`
+
+// asciiAlphanumerics are the ASCII letters and digits
+const asciiAlphanumerics = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// Presets are the built-in Presets
+var Presets = []Preset{
+	{
+		Name:        "ad",
+		Description: "Active Directory complexity requirements and the default domain policy: at least 7 characters, three of upper, lower, digit and symbol",
+		Policy:      Policy{MinLength: 7, MinClasses: 3},
+	},
+	{
+		Name:        "aws-iam",
+		Description: "AWS IAM default password policy: 8 to 128 characters, three of upper, lower, digit and symbol, the symbols of " + awsIAMSymbols + " only",
+		Policy:      Policy{MinLength: 8, MaxLength: 128, MinClasses: 3, AllowedChars: asciiAlphanumerics + awsIAMSymbols},
+	},
+	{
+		Name:        "pam",
+		Description: "Linux pam_pwquality defaults: at least 8 characters (minlen = 8, minclass = 0)",
+		Policy:      Policy{MinLength: 8},
+	},
+	{
+		Name:        "pci",
+		Description: "PCI DSS v4 requirement 8.3.6: at least 12 characters, both numeric and alphabetic",
+		Policy:      Policy{MinLength: 12, Require: Digit | Letter},
+	},
+}
+
+// PresetByName returns the Preset of the given name from Presets
+func PresetByName(name string) (Preset, bool) {
+	for _, preset := range Presets {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+	return Preset{}, false
+}
+
+// String describes the Policy, fx "8-128 characters, requires upper,lower,digit, forbids ' '"
+func (p Policy) String() string {
+	var parts []string
+	switch {
+	case p.MaxLength > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d characters", p.MinLength, p.MaxLength))
+	case p.MinLength > 0:
+		parts = append(parts, fmt.Sprintf("at least %d characters", p.MinLength))
+	}
+	if p.Require != 0 {
+		parts = append(parts, "requires "+p.Require.String())
+	}
+	if p.MinClasses > 0 {
+		parts = append(parts, fmt.Sprintf("requires %d of %s", p.MinClasses, countedClasses))
+	}
+	if p.AllowedChars != "" {
+		parts = append(parts, fmt.Sprintf("allows only %q", p.AllowedChars))
+	}
+	if p.ForbiddenChars != "" {
+		parts = append(parts, fmt.Sprintf("forbids %q", p.ForbiddenChars))
+	}
+	return strings.Join(parts, ", ")
+}
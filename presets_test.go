@@ -0,0 +1,70 @@
+package profaneword
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPresetByName(t *testing.T) {
+	for _, preset := range Presets {
+		got, found := PresetByName(preset.Name)
+		if !found || got.Name != preset.Name {
+			t.Errorf("expected to find preset %s", preset.Name)
+		}
+	}
+	if _, found := PresetByName("nope"); found {
+		t.Errorf("expected an unknown preset not to be found")
+	}
+}
+
+func TestPresets_Enforce(t *testing.T) {
+	for _, preset := range Presets {
+		got := preset.Policy.Enforce("tired-passivation!-son-of-a-bigot", WithRandom(NewSeededRand([]byte(preset.Name))))
+		if err := preset.Policy.Check(got); err != nil {
+			t.Errorf("expected preset %s to be enforced on %s: %v", preset.Name, got, err)
+		}
+	}
+}
+
+func TestPresets_Check(t *testing.T) {
+	tests := []struct {
+		preset, password string
+		compliant        bool
+	}{
+		{"ad", "Abc1234", true},
+		{"ad", "abc12!", false},
+		{"ad", "abcd1234", false},
+		{"ad", "abcd12!", true},
+		{"aws-iam", "Abcdefg1", true},
+		{"aws-iam", "abcdefg1", false},
+		{"aws-iam", "Abcdef1.", false},
+		{"pam", "abcdefgh", true},
+		{"pam", "a b c d", false},
+		{"pci", "abcdefghijk1", true},
+		{"pci", "123456789012", false},
+		{"pci", "abcdefghijkl", false},
+	}
+	for _, test := range tests {
+		preset, _ := PresetByName(test.preset)
+		if err := preset.Policy.Check(test.password); (err == nil) != test.compliant {
+			t.Errorf("unexpected compliance of %q to %s: %v", test.password, test.preset, err)
+		}
+	}
+}
+
+func TestPresets_AllowedChars(t *testing.T) {
+	preset, _ := PresetByName("aws-iam")
+	for _, r := range awsIAMSymbols + "aZ09" {
+		if !preset.Policy.Allows(r) {
+			t.Errorf("expected %q to be allowed", r)
+		}
+	}
+	for _, r := range ` "\.~§é€` {
+		if preset.Policy.Allows(r) {
+			t.Errorf("expected %q to be forbidden", r)
+		}
+	}
+	if err := preset.Policy.Check("Abcdefg1§"); err == nil || !strings.Contains(err.Error(), `'§'`) {
+		t.Errorf("expected a non-ASCII symbol to be forbidden, got %v", err)
+	}
+}
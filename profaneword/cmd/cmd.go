@@ -1,11 +1,10 @@
 package cmd
 
 import (
-	"bufio"
+	"fmt"
 	"github.com/MikkelHJuul/profaneword"
 	"github.com/MikkelHJuul/profaneword/profanities"
 	"github.com/spf13/cobra"
-	"io"
 	"os"
 	"strings"
 )
@@ -35,50 +34,74 @@ Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
   {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
 
 Args[formatters]:
-  1337            output formatted as 1337-speak
-  uber1337        output formatted with an extended 1337 alphabet
-  fat             output some t3xt wifth fat fringers
-  fst             otput sme tet writen wit haste
-  esrever         desrever tuptuo, per word [random does not apply]
-  shuffle         tuoput si ffudlehs
-  SCREAM          OUTPUT IS UPPERCASE
-  whisper         output is lowercased
-  swear           output cartoonish #%$@!! 
-  studder         o-o-output s-s-s-studdering t-text [random does not apply]
-  horse           just output horse-related words in stead[very unsafe] [random does not apply]
-  /s              sARcaSTiC OUtpUt
-	
+{{formatterHelp}}
   randomly        the next formatter is applied only randomly (per word basis) threshold is 50:50
   random          the next formatter is applied only randomly (per character basis) threshold is 50:50
                   both "random" and "randomly" are chainable onto themselves, 
                   though "randomly" must be before "random"
+  randomly:p      as randomly and random, but the next formatter is applied with probability p,
+  random:p        given as a decimal or a fraction, fx "randomly:0.2 1337" or "random:1/10 uber1337"
+  perword         the next formatter is applied to each word on its own
+
+  randomly, random and perword take what they apply to in parentheses, where "|" separates
+  formatters applied in order, fx 'randomly(0.3, 1337 | /s) | perword(shuffle) | random(1/10, SCREAM)'
 
 
 Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
 `
 	alternateDelimiters = ".-/_:$%^+=!@'`,|<>\"~\\?*&"
 	RAND                = "RAND"
+	// maxWords is the longest sentence --min-bits will extend to
+	maxWords = 256
+	// maxUniqueAttempts is the number of times a duplicate password is regenerated, when --unique is given
+	maxUniqueAttempts = 100
+	// maxPolicyAttempts is the number of times a password is regenerated to comply with the policy
+	maxPolicyAttempts = 1000
 )
 
 var (
 	profaneCmd = &cobra.Command{
-		Use:       "profaneword",
-		Short:     "A generator for profane passwords as requested by u/gatestone",
-		Long:      `profaneword is a program for generating obscene/profane passwords.`,
-		Args:      cobra.OnlyValidArgs,
-		ValidArgs: formatters,
-		Run:       profaneWords,
-		PreRun:    validateArgs,
+		Use:               "profaneword",
+		Short:             "A generator for profane passwords as requested by u/gatestone",
+		Long:              `profaneword is a program for generating obscene/profane passwords.`,
+		Args:              onlyValidFormatters,
+		ValidArgsFunction: validFormatters,
+		Run:               profaneWords,
 	}
 
 	obscure = &cobra.Command{
-		Use:       "obscure",
-		Short:     "apply formatters on std in",
-		Long:      "obscure applies formatters on stdin thus you can format any text, or post-format an output given by profaneword",
-		Args:      cobra.OnlyValidArgs,
-		ValidArgs: formatters,
-		Run:       obscureFunc,
-		PreRun:    validateArgs,
+		Use:               "obscure",
+		Short:             "apply formatters on std in",
+		Long:              "obscure applies formatters on stdin, or a file, thus you can format any text, or post-format an output given by profaneword",
+		Args:              onlyValidFormatters,
+		ValidArgsFunction: validFormatters,
+		Run:               obscureFunc,
+	}
+
+	deobscure = &cobra.Command{
+		Use:               "deobscure",
+		Short:             "undo the formatters applied by obscure",
+		Long:              "deobscure undoes the formatters on stdin, or a file, given the same args, delimiter and seed as obscure was given; where more than one text formats to the same text, the first is used and the ambiguity is reported on stderr",
+		Args:              onlyValidFormatters,
+		ValidArgsFunction: validFormatters,
+		Run:               deobscureFunc,
+	}
+
+	explain = &cobra.Command{
+		Use:               "explain",
+		Short:             "print the tree of formatters the args resolve to",
+		Long:              "explain prints the tree of formatters that the args resolve to, with the probabilities of random and randomly, thus you can see what a chain of formatters does",
+		Args:              onlyValidFormatters,
+		ValidArgsFunction: validFormatters,
+		Run:               explainFunc,
+	}
+
+	presets = &cobra.Command{
+		Use:   "presets",
+		Short: "list the password policy presets",
+		Long:  "presets lists the password policy presets, that can be given to --preset",
+		Args:  cobra.NoArgs,
+		Run:   listPresets,
 	}
 
 	version = &cobra.Command{
@@ -97,33 +120,44 @@ func errUseEnd(cmd *cobra.Command, t string) {
 	os.Exit(1)
 }
 
-func validateArgs(cmd *cobra.Command, args []string) {
-	for i, arg := range args {
-		if formatter(arg) == random {
-			if i == len(args)-1 {
-				errUseEnd(cmd, `"random" cannot be used without a formatter`)
+func profaneWords(cmd *cobra.Command, args []string) {
+	numWords := numWordsFrom(cmd)
+	minBits, _ := cmd.Flags().GetFloat64("min-bits")
+	count, _ := cmd.Flags().GetInt("count")
+	unique, _ := cmd.Flags().GetBool("unique")
+	out, err := newOutput(cmd)
+	if err != nil {
+		errUseEnd(cmd, err.Error())
+	}
+	gen, err := newGenerator(cmd, args)
+	if err != nil {
+		errUseEnd(cmd, err.Error())
+	}
+	seen := make(map[string]struct{}, count)
+	for n := 0; n < count; n++ {
+		var password generated
+		for attempt := 0; ; attempt++ {
+			password, err = gen.generateCompliant(numWords, minBits)
+			if err != nil {
+				errUseEnd(cmd, err.Error())
 			}
-			if formatter(args[i+1]) == randomly {
-				errUseEnd(cmd, `"random" cannot appear before "randomly"`)
+			if _, duplicate := seen[password.Password]; !unique || !duplicate {
+				break
 			}
-		}
-		if formatter(arg) == randomly {
-			if i == len(args)-1 {
-				errUseEnd(cmd, `"randomly" cannot be used without a formatter`)
+			if attempt == maxUniqueAttempts {
+				errUseEnd(cmd, fmt.Sprintf("could not generate %d unique passwords, only %d", count, len(seen)))
 			}
 		}
+		if unique {
+			seen[password.Password] = struct{}{}
+		}
+		if err = out.write(password); err != nil {
+			errUseEnd(cmd, err.Error())
+		}
+	}
+	if err = out.flush(); err != nil {
+		errUseEnd(cmd, err.Error())
 	}
-}
-
-func profaneWords(cmd *cobra.Command, args []string) {
-	numWords := numWordsFrom(cmd)
-	delim := getDelimiter(cmd)
-	var disallowW = disallowedWords(cmd)
-	sentencer := profanities.NewProfanitySentencer(disallowW)
-	sentence := sentencer.GetSentence(numWords)
-	text := sentencer.Sentence(sentence)
-	formatter := formatterOf(args, profaneword.RandomTitleFormatter(), profaneword.DelimiterFormatterWith(delim))
-	cmd.Println(formatter.Format(text))
 }
 
 func disallowedWords(cmd *cobra.Command) (disallowed profanities.Word) {
@@ -145,11 +179,89 @@ func disallowedWords(cmd *cobra.Command) (disallowed profanities.Word) {
 	return
 }
 
-func getDelimiter(cmd *cobra.Command) (delim string) {
+// randomDevice returns the RandomDevice to use for a single run, a SeededRand if a seed is given
+func randomDevice(cmd *cobra.Command) profaneword.RandomDevice {
+	pflags := cmd.Root().PersistentFlags()
+	if !pflags.Changed("seed") {
+		return profaneword.CryptoRand{}
+	}
+	seed, _ := pflags.GetString("seed")
+	return profaneword.NewSeededRand([]byte(seed))
+}
+
+// optionsOf returns the Options given by the flags, other than the RandomDevice:
+// the tokenizer, keyboard layout, 1337 alphabet and emoji table
+func optionsOf(cmd *cobra.Command) ([]profaneword.Option, error) {
+	tokenizer, err := tokenizerOf(cmd)
+	if err != nil {
+		return nil, err
+	}
+	name, _ := cmd.Root().PersistentFlags().GetString("layout")
+	layout, ok := profaneword.KeyboardLayoutByName(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown keyboard layout: %q", name)
+	}
+	opts := []profaneword.Option{profaneword.WithTokenizer(tokenizer), profaneword.WithKeyboardLayout(layout)}
+	if path, _ := cmd.Root().PersistentFlags().GetString("leet-alphabet"); path != "" {
+		alphabet, err := alphabetOf(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leet alphabet %s: %w", path, err)
+		}
+		opts = append(opts, profaneword.WithL337Alphabet(alphabet))
+	}
+	emojis := profaneword.DefaultEmojiTable()
+	if path, _ := cmd.Root().PersistentFlags().GetString("emoji-table"); path != "" {
+		table, err := emojiTableOf(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid emoji table %s: %w", path, err)
+		}
+		emojis = emojis.With(table)
+	}
+	return append(opts, profaneword.WithLazyEmojiTable(func() profaneword.EmojiTable { return profanities.Emojis(emojis) })), nil
+}
+
+// alphabetOf reads the 1337 alphabet of a JSON file
+func alphabetOf(path string) (profaneword.Alphabet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return profaneword.ParseAlphabetJSON(data)
+}
+
+// emojiTableOf reads the emoji table of a JSON file
+func emojiTableOf(path string) (profaneword.EmojiTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return profaneword.ParseEmojiTableJSON(data)
+}
+
+// tokenizerOf returns the Tokenizer given by the tokenizer flag
+func tokenizerOf(cmd *cobra.Command) (profaneword.Tokenizer, error) {
+	name, _ := cmd.Root().PersistentFlags().GetString("tokenizer")
+	switch name, expr, _ := strings.Cut(name, ":"); name {
+	case "whitespace":
+		return profaneword.WhitespaceTokenizer{}, nil
+	case "words":
+		return profaneword.WordTokenizer{}, nil
+	case "regexp":
+		tokenizer, err := profaneword.NewRegexpTokenizer(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tokenizer regexp: %w", err)
+		}
+		return tokenizer, nil
+	}
+	return nil, fmt.Errorf("unknown tokenizer: %q", name)
+}
+
+// getDelimiter returns the delimiter given by the flag, or a random one among the candidates if it is RAND
+func getDelimiter(cmd *cobra.Command, device profaneword.RandomDevice, candidates string) (delim string) {
 	delim, _ = cmd.PersistentFlags().GetString("delimiter")
 	if delim == RAND {
-		idx := profaneword.CryptoRand{}.RandMax(len(alternateDelimiters))
-		delim = string(alternateDelimiters[idx])
+		idx := device.RandMax(len(candidates))
+		delim = string(candidates[idx])
 	}
 	return
 }
@@ -166,24 +278,25 @@ func numWordsFrom(cmd *cobra.Command) int {
 	return int(ext)
 }
 
-func obscureFunc(cmd *cobra.Command, args []string) {
-	reader := bufio.NewReader(os.Stdin)
-	delim := getDelimiter(cmd.Root())
-	formatter := formatterOf(args, profaneword.DelimiterFormatterWith(delim))
-	for {
-		text, err := reader.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				if text != "" && text != "\n" {
-					cmd.Println(formatter.Format(text))
-				}
-				break
-			}
-			cmd.Println(err)
-			os.Exit(1)
-		}
-		text = text[:len(text)-1] // remove the newline to not print two newlines
-		cmd.Println(formatter.Format(text))
+func explainFunc(cmd *cobra.Command, args []string) {
+	options, err := optionsOf(cmd)
+	if err != nil {
+		errUseEnd(cmd, err.Error())
+	}
+	expr, _ := parseExpression(args) // validated by onlyValidFormatters
+	if len(expr.terms) == 0 {
+		fmt.Fprint(cmd.OutOrStdout(), profaneword.Describe(profaneword.UnitFormatter{}))
+		return
+	}
+	opts := append([]profaneword.Option{profaneword.WithRandom(randomDevice(cmd))}, options...)
+	formatter, _ := expr.build(opts)
+	fmt.Fprint(cmd.OutOrStdout(), profaneword.Describe(formatter))
+}
+
+func listPresets(cmd *cobra.Command, _ []string) {
+	for _, preset := range profaneword.Presets {
+		cmd.Printf("%-10s %s\n", preset.Name, preset.Description)
+		cmd.Printf("%-10s %s\n", "", preset.Policy)
 	}
 }
 
@@ -195,6 +308,16 @@ func Execute() error {
 func init() {
 	profaneCmd.AddCommand(version)
 	profaneCmd.AddCommand(obscure)
+	profaneCmd.AddCommand(deobscure)
+	profaneCmd.AddCommand(presets)
+	profaneCmd.AddCommand(explain)
+
+	obscure.Flags().String("in", "", "read the text from this file instead of stdin")
+	obscure.Flags().String("out", "", "write the formatted text to this file instead of stdout")
+	obscure.Flags().Bool("in-place", false, "replace the file given by --in with the formatted text")
+	deobscure.Flags().String("in", "", "read the text from this file instead of stdin")
+	deobscure.Flags().String("out", "", "write the text to this file instead of stdout")
+	obscure.Flags().IntP("jobs", "j", 1, "the number of lines formatted in parallel, each worker has its own random device; with --seed the output depends on the number of jobs")
 
 	profaneCmd.PersistentFlags().Int16P("extensiveness", "e", 2, "how long (number of words) the password should be. Default is 2")
 	profaneCmd.PersistentFlags().Bool("extend", false, "lengthen the output (extensiveness+1)")
@@ -205,5 +328,40 @@ func init() {
 	profaneCmd.PersistentFlags().String("no", "", "exclude types of words: can be MISSPELL, POSITIVE or a '|' separated text of those")
 	profaneCmd.PersistentFlags().Bool("weird", false, "allow WEIRD misspellings, like ed-ing: 'd' and ly-endings: 'lee', 'le', 'li'")
 
+	profaneCmd.PersistentFlags().String("seed", "", "seed the random decisions; the same seed and arguments always give the same output [unsafe for real passwords]")
+	profaneCmd.PersistentFlags().String("layout", profaneword.KeyboardLayouts[0].Name, "the keyboard layout fat fingers type on: "+profaneword.LayoutNames())
+	_ = profaneCmd.RegisterFlagCompletionFunc("layout", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for _, layout := range profaneword.KeyboardLayouts {
+			names = append(names, layout.Name+"\t"+layout.Description)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	profaneCmd.PersistentFlags().String("leet-alphabet", "", "a .json file of the 1337 alphabet for 1337 and uber1337, letters to alternatives, fx: {\"A\": [\"4\", {\"text\": \"@\", \"weight\": 2}]}")
+	_ = profaneCmd.MarkPersistentFlagFilename("leet-alphabet", "json")
+	profaneCmd.PersistentFlags().String("emoji-table", "", "a .json file of keywords to emoji, extending the table of the emoji formatter, fx: {\"horse\": \"🐎\"}")
+	_ = profaneCmd.MarkPersistentFlagFilename("emoji-table", "json")
+	profaneCmd.PersistentFlags().String("tokenizer", "whitespace", "how per word formatters split the text into words: whitespace, words (Unicode word boundaries) or regexp:<expr> matching the words")
+
+	profaneCmd.Flags().Bool("entropy", false, "print the estimated bits of entropy spent generating the password")
+	profaneCmd.Flags().StringP("output", "o", textOutput, "the output format: "+textOutput+", "+jsonOutput+" (one object per line) or "+csvOutput)
+	profaneCmd.Flags().IntP("count", "n", 1, "the number of passwords to generate")
+	profaneCmd.Flags().Bool("unique", false, "guarantee that no password is generated twice, when generating more than one")
+	profaneCmd.Flags().String("preset", "", "policy: a preset password policy, list them with: profaneword presets")
+	_ = profaneCmd.RegisterFlagCompletionFunc("preset", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for _, preset := range profaneword.Presets {
+			names = append(names, preset.Name+"\t"+preset.Description)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	profaneCmd.Flags().Int("min-length", 0, "policy: the minimum number of characters in the password")
+	profaneCmd.Flags().Int("max-length", 0, "policy: the maximum number of characters in the password, 0 is unlimited")
+	profaneCmd.Flags().String("require", "", "policy: comma separated classes of characters the password must contain: upper, lower, digit, symbol, letter")
+	profaneCmd.Flags().Int("min-classes", 0, "policy: the minimum number of distinct classes of upper, lower, digit and symbol in the password")
+	profaneCmd.Flags().String("forbid-chars", "", `policy: characters the password must not contain, fx '"\ ' forbids quotes, backslash and space`)
+	profaneCmd.Flags().Float64("min-bits", 0, "extend the password until it has at least this many bits of entropy, as printed by --entropy; the bits lost when --unique or a policy rejects a password are not subtracted")
+
+	cobra.AddTemplateFunc("formatterHelp", formatterHelp)
 	profaneCmd.SetUsageTemplate(usageTpl)
 }
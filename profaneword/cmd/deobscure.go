@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MikkelHJuul/profaneword"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strings"
+)
+
+func deobscureFunc(cmd *cobra.Command, args []string) {
+	options, err := optionsOf(cmd)
+	if err != nil {
+		errUseEnd(cmd, err.Error())
+	}
+	flags := cmd.Flags()
+	in, _ := flags.GetString("in")
+	out, _ := flags.GetString("out")
+	seeded := cmd.Root().PersistentFlags().Changed("seed")
+	counter := profaneword.NewEntropyCounter(randomDevice(cmd))
+	delim := getDelimiter(cmd.Root(), counter, alternateDelimiters)
+	opts := append([]profaneword.Option{profaneword.WithRandom(counter)}, options...)
+	formatter, _ := formatterOf(args, opts, profaneword.DelimiterFormatterWith(delim)) // validated by onlyValidFormatters
+	n, others := randomFormatters(formatter)
+	if n > 1 {
+		errUseEnd(cmd, fmt.Sprintf("deobscure can replay the decisions of a single random or randomly, got %d", n))
+	} else if n > 0 && others > 0 {
+		errUseEnd(cmd, "deobscure cannot replay the decisions of random or randomly together with 1337 alternatives or zalgo marks")
+	} else if !seeded && (n > 0 || counter.Bits() > 0) {
+		errUseEnd(cmd, "the formatters make random decisions, give the --seed used to obscure the text")
+	}
+	inverse := &invertingFormatter{formatter: formatter, counts: map[string]int{}}
+	err = obscureFile(cmd, in, out, func(w io.Writer) io.WriteCloser {
+		return profaneword.NewWriter(w, inverse)
+	})
+	if err == nil {
+		err = inverse.err
+	}
+	if err != nil {
+		cmd.PrintErrln(err)
+		os.Exit(1)
+	}
+	for _, ambiguity := range inverse.ambiguities {
+		candidates := make([]string, len(ambiguity.Candidates))
+		for i, candidate := range ambiguity.Candidates {
+			candidates[i] = fmt.Sprintf("%q", candidate)
+		}
+		times := "once"
+		if n := inverse.counts[keyOf(ambiguity)]; n > 1 {
+			times = fmt.Sprintf("%d times", n)
+		}
+		cmd.PrintErrf("%q could be %s (%s)\n", ambiguity.Formatted, strings.Join(candidates, " or "), times)
+	}
+}
+
+// randomFormatters returns the number of formatters that decide randomly, per text or per character, when formatting,
+// and the number of other formatters that decide randomly, which are not replayed: 1337 alternatives and zalgo marks
+func randomFormatters(formatter profaneword.Formatter) (n, others int) {
+	profaneword.Walk(formatter, func(f interface{}, _ int) {
+		switch f := f.(type) {
+		case *profaneword.RandomlyFormattingFormatter, *profaneword.RandomlyFormattingCharFormatter:
+			n++
+		case interface{ Alphabet() profaneword.Alphabet }:
+			if f.Alphabet().Random() {
+				others++
+			}
+		case profaneword.ZalgoCharFormatter:
+			others++
+		}
+	})
+	return
+}
+
+// invertingFormatter is a Formatter that inverts the text by the formatter, it keeps the first error
+// and counts the ambiguities, in the order they are first found
+type invertingFormatter struct {
+	formatter   profaneword.Formatter
+	err         error
+	ambiguities []profaneword.Ambiguity
+	counts      map[string]int
+}
+
+func (i *invertingFormatter) Format(text string) string {
+	if i.err != nil {
+		return ""
+	}
+	text, ambiguities, err := profaneword.Invert(i.formatter, text)
+	if err != nil {
+		i.err = err
+		return ""
+	}
+	for _, ambiguity := range ambiguities {
+		key := keyOf(ambiguity)
+		if i.counts[key] == 0 {
+			i.ambiguities = append(i.ambiguities, ambiguity)
+		}
+		i.counts[key]++
+	}
+	return text
+}
+
+func keyOf(ambiguity profaneword.Ambiguity) string {
+	return fmt.Sprintf("%q%q", ambiguity.Formatted, ambiguity.Candidates)
+}
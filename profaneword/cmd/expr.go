@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MikkelHJuul/profaneword"
+)
+
+// expression is the formatter arguments, joined by spaces, parsed as a pipeline of terms that are applied in order.
+// The grammar is:
+//
+//	pipeline = term { [ "|" ] term }
+//	term     = name [ ":" param ] [ "(" [ param "," ] pipeline ")" ]
+//
+// A combinator (randomly, random or perword) without parentheses applies to the single term following it,
+// such that the plain arguments "randomly:0.3 1337 /s" are the same as "randomly(0.3, 1337) | /s"
+type expression struct {
+	text  string
+	terms []term
+}
+
+// term is a formatter, or a combinator applied to the pipeline of inner terms
+type term struct {
+	// pos is the byte offset of the term in the text of the expression
+	pos   int
+	name  formatter
+	param string
+	inner []term
+}
+
+// exprError is an error at a position of an expression, its message points out the position
+type exprError struct {
+	text string
+	pos  int
+	msg  string
+}
+
+func (e *exprError) Error() string {
+	column := utf8.RuneCountInString(e.text[:e.pos])
+	return fmt.Sprintf("%s, at position %d:\n  %s\n  %s^", e.msg, column+1, e.text, strings.Repeat(" ", column))
+}
+
+func (e *expression) errorf(pos int, format string, a ...interface{}) error {
+	return &exprError{text: e.text, pos: pos, msg: fmt.Sprintf(format, a...)}
+}
+
+// parseExpression parses the formatter arguments, an empty list of arguments is an empty expression
+func parseExpression(args []string) (*expression, error) {
+	p := &parser{expression: expression{text: strings.Join(args, " ")}}
+	p.skipSpace()
+	if p.peek() == 0 {
+		return &p.expression, nil
+	}
+	terms, err := p.pipeline()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != 0 {
+		return nil, p.errorf(p.pos, "unexpected %q", p.text[p.pos])
+	}
+	p.terms = terms
+	return &p.expression, nil
+}
+
+type parser struct {
+	expression
+	pos int
+}
+
+// separators are the characters that end a name or parameter
+const separators = " \t\n(),|"
+
+func (p *parser) peek() byte {
+	if p.pos == len(p.text) {
+		return 0
+	}
+	return p.text[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for c := p.peek(); c == ' ' || c == '\t' || c == '\n'; c = p.peek() {
+		p.pos++
+	}
+}
+
+// word returns the name or parameter at the position
+func (p *parser) word() string {
+	start := p.pos
+	for c := p.peek(); c != 0 && strings.IndexByte(separators, c) == -1; c = p.peek() {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+// endOfPipeline is whether the next character ends a pipeline, or if it is missing a term
+func (p *parser) endOfPipeline() bool {
+	c := p.peek()
+	return c == 0 || c == ')' || c == ',' || c == '|'
+}
+
+func (p *parser) pipeline() ([]term, error) {
+	var terms []term
+	for {
+		p.skipSpace()
+		if pipe := p.pos; p.peek() == '|' {
+			p.pos++
+			p.skipSpace()
+			if len(terms) == 0 || p.endOfPipeline() {
+				return nil, p.errorf(pipe, `"|" must be between two formatters`)
+			}
+		} else if p.endOfPipeline() {
+			if len(terms) == 0 {
+				return nil, p.errorf(p.pos, "expected a formatter")
+			}
+			return terms, nil
+		}
+		t, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+}
+
+func (p *parser) term() (term, error) {
+	pos := p.pos
+	word := p.word()
+	if word == "" {
+		return term{}, p.errorf(pos, "expected a formatter, got %q", p.text[pos])
+	}
+	name, param, _ := strings.Cut(word, ":")
+	t := term{pos: pos, name: formatter(name), param: param}
+	if _, ok := profaneword.Lookup(name); !ok && !combinators[t.name] {
+		return t, p.errorf(pos, "unknown formatter %q", name)
+	}
+	p.skipSpace()
+	if p.peek() == '(' {
+		open := p.pos
+		p.pos++
+		if err := p.arguments(&t); err != nil {
+			return t, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return t, p.errorf(p.pos, `expected ")" to close %q`, p.text[pos:open+1])
+		}
+		p.pos++
+		return t, nil
+	}
+	if combinators[t.name] {
+		if p.endOfPipeline() {
+			return t, p.errorf(pos, "%q must be followed by the formatter it applies to", name)
+		}
+		inner, err := p.term()
+		if err != nil {
+			return t, err
+		}
+		t.inner = []term{inner}
+	}
+	return t, nil
+}
+
+// arguments parses the arguments within the parentheses of a term: a parameter, a pipeline, or a parameter and a pipeline
+func (p *parser) arguments(t *term) error {
+	p.skipSpace()
+	start := p.pos
+	word := p.word()
+	p.skipSpace()
+	if word != "" && (p.peek() == ',' || p.peek() == ')' && !combinators[t.name]) {
+		if t.param != "" {
+			return p.errorf(start, "%q is given a parameter twice", t.name)
+		}
+		t.param = word
+		if p.peek() == ')' {
+			return nil
+		}
+		p.pos++ // the ','
+	} else {
+		p.pos = start
+	}
+	if !combinators[t.name] {
+		return p.errorf(p.pos, "%q does not apply to other formatters", t.name)
+	}
+	inner, err := p.pipeline()
+	t.inner = inner
+	return err
+}
+
+// build returns the Formatter of the expression, the options are passed on to each of the formatters
+func (e *expression) build(opts []profaneword.Option) (profaneword.Formatter, error) {
+	return e.buildPipeline(e.terms, opts)
+}
+
+func (e *expression) buildPipeline(terms []term, opts []profaneword.Option) (profaneword.Formatter, error) {
+	if len(terms) == 1 {
+		return e.buildTerm(terms[0], opts)
+	}
+	mulF := &profaneword.MultiFormatter{}
+	for _, t := range terms {
+		f, err := e.buildTerm(t, opts)
+		if err != nil {
+			return nil, err
+		}
+		mulF.With(f)
+	}
+	return mulF, nil
+}
+
+func (e *expression) buildTerm(t term, opts []profaneword.Option) (profaneword.Formatter, error) {
+	if !combinators[t.name] {
+		reg, _ := profaneword.Lookup(string(t.name))
+		f, err := reg.Factory(t.param, opts...)
+		if err != nil {
+			return nil, e.errorf(t.pos, "%q %v", t.name, err)
+		}
+		return f, nil
+	}
+	if t.name == perword && t.param != "" {
+		return nil, e.errorf(t.pos, "%q takes no parameter", t.name)
+	}
+	inner, err := e.buildPipeline(t.inner, opts)
+	if err != nil {
+		return nil, err
+	}
+	switch t.name {
+	case perword:
+		return profaneword.NewPerWordFormatter(inner, opts...), nil
+	case randomly:
+		threshold, err := thresholdOf(t.param)
+		if err != nil {
+			return nil, e.errorf(t.pos, "%v", err)
+		}
+		return profaneword.NewRandomlyFormatter(inner, append(opts[:len(opts):len(opts)], threshold)...), nil
+	default: // random
+		threshold, err := thresholdOf(t.param)
+		if err != nil {
+			return nil, e.errorf(t.pos, "%v", err)
+		}
+		formatter, ok := randomPerChar(inner, append(opts[:len(opts):len(opts)], threshold))
+		if !ok {
+			if len(t.inner) > 1 {
+				return nil, e.errorf(t.inner[0].pos, "%q applies per character, but a pipeline does not format per character", random)
+			}
+			if combinators[t.inner[0].name] {
+				return nil, e.errorf(t.inner[0].pos, "%q applies per character, but %q does not format per character", random, t.inner[0].name)
+			}
+			return nil, e.errorf(t.inner[0].pos, "%q applies per character, but %q does not format per character, use %q to apply it per word", random, t.inner[0].name, randomly)
+		}
+		return formatter, nil
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MikkelHJuul/profaneword"
+)
+
+// describe returns the description of the formatter of the args, without the MultiFormatter of formatterOf
+func describe(t *testing.T, args ...string) string {
+	t.Helper()
+	expr, err := parseExpression(args)
+	if err != nil {
+		t.Fatalf("unexpected error parsing %q: %v", args, err)
+	}
+	formatter, err := expr.build(nil)
+	if err != nil {
+		t.Fatalf("unexpected error building %q: %v", args, err)
+	}
+	return profaneword.Describe(formatter)
+}
+
+func TestParseExpression_FlatArgs(t *testing.T) {
+	tests := []struct {
+		flat     []string
+		pipeline string
+	}{
+		{[]string{"SCREAM"}, "SCREAM"},
+		{[]string{"1337", "esrever"}, "1337 | esrever"},
+		{[]string{"randomly", "1337", "/s"}, "randomly(1337) | /s"},
+		{[]string{"randomly:0.3", "1337", "/s"}, "randomly(0.3, 1337) | /s"},
+		{[]string{"random:1/10", "uber1337"}, "random(1/10, uber1337)"},
+		{[]string{"randomly", "random", "SCREAM"}, "randomly(random(SCREAM))"},
+		{[]string{"perword", "shuffle", "whisper"}, "perword(shuffle) | whisper"},
+		{[]string{"zalgo:0.2", "fat:qwertz"}, "zalgo(0.2) | fat(qwertz)"},
+	}
+	for _, test := range tests {
+		flat, pipeline := describe(t, test.flat...), describe(t, test.pipeline)
+		if flat != pipeline {
+			t.Errorf("expected %q to be %q:\n%s\ngot\n%s", test.flat, test.pipeline, pipeline, flat)
+		}
+	}
+}
+
+func TestParseExpression_Terms(t *testing.T) {
+	expr, err := parseExpression([]string{"randomly(0.3, 1337 | /s) | perword(shuffle)", "random(1/10,", "SCREAM)", "zalgo:0.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shape func(terms []term) string
+	shape = func(terms []term) string {
+		parts := make([]string, len(terms))
+		for i, t := range terms {
+			parts[i] = string(t.name)
+			if t.param != "" {
+				parts[i] += ":" + t.param
+			}
+			if t.inner != nil {
+				parts[i] += "[" + shape(t.inner) + "]"
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	if got, expected := shape(expr.terms), "randomly:0.3[1337 /s] perword[shuffle] random:1/10[SCREAM] zalgo:0.2"; got != expected {
+		t.Errorf("expected the terms %s, got %s", expected, got)
+	}
+	for _, t2 := range []term{expr.terms[0], expr.terms[0].inner[1], expr.terms[2], expr.terms[3]} {
+		if !strings.HasPrefix(expr.text[t2.pos:], string(t2.name)) {
+			t.Errorf("expected %s at position %d of %q", t2.name, t2.pos, expr.text)
+		}
+	}
+}
+
+func TestParseExpression_Format(t *testing.T) {
+	tests := []struct {
+		args     []string
+		text     string
+		expected string
+	}{
+		{[]string{}, "hello", "hello"},
+		{[]string{"SCREAM | esrever"}, "hello world", "OLLEH DLROW"},
+		{[]string{"esrever", "SCREAM"}, "hello world", "OLLEH DLROW"},
+		{[]string{"perword(shuffle) | whisper"}, "AB", "ab"},
+		{[]string{"perword(esrever)"}, "ab cd", "ba dc"},
+		{[]string{"randomly(1, SCREAM)"}, "hello", "HELLO"},
+		{[]string{"randomly(0, SCREAM)"}, "hello", "hello"},
+		{[]string{"random:1", "whisper"}, "HELLO", "hello"},
+		{[]string{"random(0, whisper)"}, "HELLO", "HELLO"},
+		{[]string{"perword(randomly:1 esrever)"}, "ab cd", "ba dc"},
+	}
+	for _, test := range tests {
+		formatter, err := formatterOf(test.args, []profaneword.Option{profaneword.WithRandom(profaneword.NewSeededRand([]byte("test")))})
+		if err != nil {
+			t.Errorf("unexpected error of %q: %v", test.args, err)
+			continue
+		}
+		if got := formatter.Format(test.text); got != test.expected {
+			t.Errorf("expected %q to format %q as %q, got %q", test.args, test.text, test.expected, got)
+		}
+	}
+}
+
+func TestParseExpression_Errors(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"random", "esrever"}, `"random" applies per character, but "esrever" does not format per character, use "randomly" to apply it per word, at position 8:
+  random esrever
+         ^`},
+		{[]string{"zalgo:3"}, `"zalgo" invalid probability "3": must be a number between 0 and 1, fx 0.2 or 1/10, at position 1:
+  zalgo:3
+  ^`},
+		{[]string{"SCREAM", "nope"}, `unknown formatter "nope", at position 8:
+  SCREAM nope
+         ^`},
+		{[]string{"SCREAM |"}, `"|" must be between two formatters, at position 8:
+  SCREAM |
+         ^`},
+		{[]string{"| SCREAM"}, `"|" must be between two formatters, at position 1:
+  | SCREAM
+  ^`},
+		{[]string{"randomly(0.3, 1337"}, `expected ")" to close "randomly(", at position 19:
+  randomly(0.3, 1337
+                    ^`},
+		{[]string{"1337 ) x"}, `unexpected ')', at position 6:
+  1337 ) x
+       ^`},
+		{[]string{"randomly"}, `"randomly" must be followed by the formatter it applies to, at position 1:
+  randomly
+  ^`},
+		{[]string{"SCREAM(esrever)"}, `"SCREAM" takes no parameter, at position 1:
+  SCREAM(esrever)
+  ^`},
+		{[]string{"SCREAM(esrever | 1337)"}, `"SCREAM" does not apply to other formatters, at position 8:
+  SCREAM(esrever | 1337)
+         ^`},
+		{[]string{"randomly:0.2(0.3, 1337)"}, `"randomly" is given a parameter twice, at position 14:
+  randomly:0.2(0.3, 1337)
+               ^`},
+		{[]string{"perword:3(shuffle)"}, `"perword" takes no parameter, at position 1:
+  perword:3(shuffle)
+  ^`},
+		{[]string{"random(0.3, 1337 | SCREAM)"}, `"random" applies per character, but a pipeline does not format per character, at position 13:
+  random(0.3, 1337 | SCREAM)
+              ^`},
+		{[]string{"randomly:2", "1337"}, `invalid probability "2": must be a number between 0 and 1, fx 0.2 or 1/10, at position 1:
+  randomly:2 1337
+  ^`},
+		{[]string{"æøå"}, `unknown formatter "æøå", at position 1:
+  æøå
+  ^`},
+		{[]string{"SCREAM", "æøå"}, `unknown formatter "æøå", at position 8:
+  SCREAM æøå
+         ^`},
+	}
+	for _, test := range tests {
+		_, err := formatterOf(test.args, nil)
+		if err == nil {
+			t.Errorf("expected an error of %q", test.args)
+			continue
+		}
+		if err.Error() != test.expected {
+			t.Errorf("unexpected error of %q:\n%s\nexpected\n%s", test.args, err, test.expected)
+		}
+	}
+}
@@ -1,120 +1,122 @@
 package cmd
 
 import (
+	"fmt"
+	"math/big"
+	"strings"
+
 	"github.com/MikkelHJuul/profaneword"
+	"github.com/spf13/cobra"
 )
 
 type formatter string
 
+// the combinators, that apply to other formatters, all other formatters are looked up in the profaneword registry
 const (
-	sarcastic   formatter = "/s"
-	l337        formatter = "1337"
-	uberL337    formatter = "uber1337"
-	fatFingers  formatter = "fat"
-	fastFingers formatter = "fst"
-	scream      formatter = "SCREAM"
-	whisper     formatter = "whisper"
-	reverse     formatter = "esrever"
-	swear       formatter = "swear"
-	studder     formatter = "studder"
-	shuffle     formatter = "shuffle"
-	horse       formatter = "horse"
-	randomly    formatter = "randomly"
-	random      formatter = "random"
+	randomly formatter = "randomly"
+	random   formatter = "random"
+	perword  formatter = "perword"
 )
 
-var formatters = []string{
-	string(l337), string(uberL337), string(sarcastic),
-	string(scream), string(whisper), string(randomly),
-	string(random), string(fatFingers), string(fastFingers),
-	string(reverse), string(swear), string(studder),
-	string(horse), string(shuffle),
-}
-
-type formatFunc func([]string, int) (int, profaneword.Formatter)
+// combinators are the formatters that apply to other formatters
+var combinators = map[formatter]bool{randomly: true, random: true, perword: true}
 
-type plainFormatter func() profaneword.Formatter
-
-func (p plainFormatter) formatF() formatFunc {
-	return func(strings []string, i int) (int, profaneword.Formatter) {
-		return i, p()
-	}
+// combinatorHelp is the completion help of the combinators
+var combinatorHelp = []struct{ name, description string }{
+	{string(randomly), "the next formatter is applied only randomly (per word basis) threshold is 50:50"},
+	{string(random), "the next formatter is applied only randomly (per character basis) threshold is 50:50"},
+	{string(perword), "the next formatter is applied to each word on its own"},
 }
 
-var formatterFuncs map[formatter]formatFunc
-
-func init() {
-	formatterFuncs = map[formatter]formatFunc{
-		sarcastic:   plainFormatter(profaneword.NewSarcasticFormatter).formatF(),
-		l337:        plainFormatter(profaneword.L337Formatter).formatF(),
-		uberL337:    plainFormatter(profaneword.Uber1337Formatter).formatF(),
-		fatFingers:  plainFormatter(profaneword.NewFatFingerFormatter).formatF(),
-		fastFingers: plainFormatter(profaneword.NewFastFingerFormatter).formatF(),
-		scream:      plainFormatter(profaneword.NewUppercaseFormatter).formatF(),
-		whisper:     plainFormatter(profaneword.NewLowercaseFormatter).formatF(),
-		reverse:     plainFormatter(profaneword.NewWordReversingFormatter).formatF(),
-		swear:       plainFormatter(profaneword.NewSwearFormatter).formatF(),
-		studder:     plainFormatter(profaneword.NewStudderFormatter).formatF(),
-		horse:       plainFormatter(profaneword.NewHorseFormatter).formatF(),
-		shuffle:     plainFormatter(profaneword.NewShuffleFormatter).formatF(),
-		randomly:    getRandomlyFormatter,
-		random:      getRandomFormatter,
+// validFormatters is a cobra completion function of the names of the formatters
+func validFormatters(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, reg := range profaneword.Registered() {
+		names = append(names, reg.Name+"\t"+reg.Description)
+	}
+	for _, c := range combinatorHelp {
+		names = append(names, c.name+"\t"+c.description)
 	}
+	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
-func formatterOf(args []string, formatters ...profaneword.Formatter) profaneword.Formatter {
-	mulF := &profaneword.MultiFormatter{Formatters: formatters}
-	for i := 0; i < len(args); i++ {
-		var formatter profaneword.Formatter
-		i, formatter = getFormatter(args, i)
-		mulF.With(formatter)
+// formatterHelp returns the help text of the registered formatters, for the usage template
+func formatterHelp() string {
+	sb := strings.Builder{}
+	for _, reg := range profaneword.Registered() {
+		description := reg.Description
+		if reg.Flags&profaneword.PerCharacter == 0 {
+			description += " [random does not apply]"
+		}
+		if reg.Flags&profaneword.Unsafe != 0 {
+			description += " [very unsafe]"
+		}
+		fmt.Fprintf(&sb, "  %-15s %s\n", reg.Name, description)
 	}
-	return mulF
+	return sb.String()
 }
 
-func getFormatter(args []string, i int) (int, profaneword.Formatter) {
-	if i == len(args) {
-		return i, profaneword.UnitFormatter{}
+// thresholdOf returns the threshold option of the probability p of applying a formatter, of "random:p" or "randomly:p",
+// p is a decimal or a fraction, fx 0.2 or 1/10, the default is 1/2
+func thresholdOf(param string) (profaneword.Option, error) {
+	if param == "" {
+		return profaneword.WithThreshold(big.NewRat(1, 2)), nil
 	}
-	if formatterFunc, ok := formatterFuncs[formatter(args[i])]; ok {
-		return formatterFunc(args, i)
+	p, err := profaneword.ParseProbability(param)
+	if err != nil {
+		return nil, err
 	}
-	return i, profaneword.UnitFormatter{}
+	return profaneword.WithThreshold(p.Sub(big.NewRat(1, 1), p)), nil
 }
 
-func getRandomlyFormatter(args []string, i int) (int, profaneword.Formatter) {
-	i++
-	var wrappedFormatter profaneword.Formatter
-	i, wrappedFormatter = getFormatter(args, i)
-	randomlyFormatter := profaneword.NewRandomlyFormatter(wrappedFormatter)
-	return i, randomlyFormatter
+// onlyValidFormatters is a cobra.PositionalArgs, that validates the formatter arguments as an expression
+func onlyValidFormatters(_ *cobra.Command, args []string) error {
+	_, err := formatterOf(args, nil)
+	return err
+}
+
+// formatterOf returns a MultiFormatter of the given formatters followed by the formatters of the expression in args,
+// the options are passed on to each of the formatters in args
+func formatterOf(args []string, opts []profaneword.Option, formatters ...profaneword.Formatter) (profaneword.Formatter, error) {
+	expr, err := parseExpression(args)
+	if err != nil {
+		return nil, err
+	}
+	mulF := &profaneword.MultiFormatter{Formatters: formatters}
+	if len(expr.terms) > 0 {
+		formatter, err := expr.build(opts)
+		if err != nil {
+			return nil, err
+		}
+		mulF.With(formatter)
+	}
+	return mulF, nil
 }
 
-func getRandomFormatter(args []string, i int) (int, profaneword.Formatter) {
-	i++
-	var wrappedFormatter profaneword.Formatter
-	i, wrappedFormatter = getFormatter(args, i)
+// randomPerChar wraps the CharFormatter of the formatter, such that it is applied randomly per character.
+// It returns false if the formatter does not format per character
+func randomPerChar(wrappedFormatter profaneword.Formatter, opts []profaneword.Option) (profaneword.Formatter, bool) {
 	charFormatter, ok := wrappedFormatter.(profaneword.CharFormatter)
 	if !ok {
 		if delegating, isType := wrappedFormatter.(profaneword.WrappingFormatter); isType {
 			if charFormatter, ok = delegating.GetFormatter().(profaneword.CharFormatter); ok {
-				formatterToWrap := wrapRandom(charFormatter)
+				formatterToWrap := wrapRandom(charFormatter, opts)
 				delegating.SetFormatter(formatterToWrap)
-				return i, delegating
+				return delegating, true
 			}
 		}
-		return i, wrappedFormatter
+		return wrappedFormatter, false
 	}
-	wrapped := wrapRandom(charFormatter)
+	wrapped := wrapRandom(charFormatter, opts)
 	if delegating, isType := wrappedFormatter.(profaneword.WrappingFormatter); isType {
 		delegating.SetFormatter(wrapped)
-		return i, delegating
+		return delegating, true
 	}
-	return i, wrapped
+	return wrapped, true
 }
 
-func wrapRandom(charFormatter profaneword.CharFormatter) profaneword.Formatter {
-	randomFormatter := profaneword.NewRandomFormatter()
+func wrapRandom(charFormatter profaneword.CharFormatter, opts []profaneword.Option) profaneword.Formatter {
+	randomFormatter := profaneword.NewRandomFormatter(opts...)
 	if delegating, isType := charFormatter.(profaneword.WrappingCharFormatter); isType {
 		randomFormatter.SetCharFormatter(delegating.GetCharFormatter()) // we don't want a circular reference
 		delegating.SetCharFormatter(randomFormatter)
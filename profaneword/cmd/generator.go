@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MikkelHJuul/profaneword"
+	"github.com/MikkelHJuul/profaneword/profanities"
+	"github.com/spf13/cobra"
+)
+
+// generator generates passwords, reusing the sentencer and the formatter chain for each password
+type generator struct {
+	cmd       *cobra.Command
+	args      []string
+	opts      []profaneword.Option
+	entropy   *profaneword.EntropyCounter
+	sentencer profanities.ProfanitySentencer
+	title     profaneword.Formatter
+	// formatters are the pipeline of the formatter chain, applied in order
+	formatters []profaneword.Formatter
+	policy     *profaneword.Policy
+	// delimiters are the delimiters to choose among, for RAND or when the policy forbids the given delimiter
+	delimiters string
+	// setupBits is the entropy spent building the formatter chain, which applies to every password
+	setupBits float64
+}
+
+func newGenerator(cmd *cobra.Command, args []string) (*generator, error) {
+	policy, err := policyOf(cmd)
+	if err != nil {
+		return nil, err
+	}
+	options, err := optionsOf(cmd)
+	if err != nil {
+		return nil, err
+	}
+	entropy := profaneword.NewEntropyCounter(randomDevice(cmd))
+	opts := append([]profaneword.Option{profaneword.WithRandom(entropy)}, options...)
+	formatter, err := formatterOf(args, opts)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		cmd:        cmd,
+		args:       args,
+		opts:       opts,
+		entropy:    entropy,
+		sentencer:  profanities.NewProfanitySentencer(disallowedWords(cmd), opts...),
+		title:      profaneword.RandomTitleFormatter(opts...),
+		formatters: pipelineOf(formatter),
+		policy:     policy,
+		delimiters: alternateDelimiters,
+	}
+	if policy != nil {
+		g.delimiters = policy.Delimiters(alternateDelimiters)
+		if g.delimiters == "" {
+			return nil, fmt.Errorf("the policy forbids all delimiters")
+		}
+		if delim, _ := cmd.PersistentFlags().GetString("delimiter"); cmd.PersistentFlags().Changed("delimiter") && delim != RAND && strings.IndexFunc(delim, func(r rune) bool { return !policy.Allows(r) }) != -1 {
+			return nil, fmt.Errorf("the delimiter %q is forbidden by the policy", delim)
+		}
+	}
+	g.setupBits = entropy.Bits()
+	return g, nil
+}
+
+// policyOf returns the Policy given by the preset and policy flags, or nil if none are given.
+// The policy flags override the rules of the preset
+func policyOf(cmd *cobra.Command) (*profaneword.Policy, error) {
+	flags := cmd.Flags()
+	if !flags.Changed("preset") && !flags.Changed("min-length") && !flags.Changed("max-length") && !flags.Changed("require") && !flags.Changed("min-classes") && !flags.Changed("forbid-chars") {
+		return nil, nil
+	}
+	policy := &profaneword.Policy{}
+	if flags.Changed("preset") {
+		name, _ := flags.GetString("preset")
+		preset, found := profaneword.PresetByName(name)
+		if !found {
+			return nil, fmt.Errorf("unknown preset: %s, see: profaneword presets", name)
+		}
+		policy = &preset.Policy
+	}
+	if flags.Changed("min-length") {
+		policy.MinLength, _ = flags.GetInt("min-length")
+	}
+	if flags.Changed("max-length") {
+		policy.MaxLength, _ = flags.GetInt("max-length")
+	}
+	if flags.Changed("forbid-chars") {
+		policy.ForbiddenChars, _ = flags.GetString("forbid-chars")
+	}
+	if flags.Changed("require") {
+		require, _ := flags.GetString("require")
+		var err error
+		if policy.Require, err = profaneword.ParseCharClasses(require); err != nil {
+			return nil, err
+		}
+	}
+	if flags.Changed("min-classes") {
+		policy.MinClasses, _ = flags.GetInt("min-classes")
+	}
+	if policy.MaxLength > 0 && policy.MaxLength < policy.MinLength {
+		return nil, fmt.Errorf("the maximum length %d is less than the minimum length %d", policy.MaxLength, policy.MinLength)
+	}
+	return policy, nil
+}
+
+// delimiter returns the delimiter to use for a single password, a random allowed delimiter
+// if RAND is given, or if the policy forbids the default delimiter
+func (g *generator) delimiter() string {
+	delim := getDelimiter(g.cmd, g.entropy, g.delimiters)
+	if g.policy != nil && strings.IndexFunc(delim, func(r rune) bool { return !g.policy.Allows(r) }) != -1 {
+		delim = string(g.delimiters[g.entropy.RandMax(len(g.delimiters))])
+	}
+	return delim
+}
+
+// pipelineOf returns the formatters of a pipeline of MultiFormatters, in the order they are applied
+func pipelineOf(formatter profaneword.Formatter) []profaneword.Formatter {
+	mulF, ok := formatter.(*profaneword.MultiFormatter)
+	if !ok {
+		return []profaneword.Formatter{formatter}
+	}
+	var formatters []profaneword.Formatter
+	for _, f := range mulF.Formatters {
+		formatters = append(formatters, pipelineOf(f)...)
+	}
+	return formatters
+}
+
+// generated is a generated password, with the details of how it was generated
+type generated struct {
+	Password   string   `json:"password"`
+	Sentence   string   `json:"sentence"`
+	Formats    []string `json:"formats"`
+	Words      []string `json:"words"`
+	Formatters []string `json:"formatters"`
+	Length     int      `json:"length"`
+	Entropy    float64  `json:"entropy"`
+}
+
+// generate returns a formatted password of numWords words
+func (g *generator) generate(numWords int) generated {
+	g.entropy.Reset()
+	sentence := g.sentencer.GetSentence(numWords)
+	text := g.sentencer.Sentence(sentence)
+	delim := g.delimiter()
+	password := text
+	for _, formatter := range append([]profaneword.Formatter{g.title, profaneword.DelimiterFormatterWith(delim)}, g.formatters...) {
+		// the decisions erased by the formatter no longer change the password, and are not counted
+		g.entropy.Erase(profaneword.ErasedBy(formatter))
+		g.entropy.Decide(profaneword.DecisionsOf(formatter))
+		password = formatter.Format(password)
+	}
+	if g.policy != nil {
+		g.entropy.Decide(profaneword.TextDecisions)
+		password = g.policy.Enforce(password, g.opts...)
+	}
+	var words []string
+	for _, w := range sentence.ChosenTypes() {
+		words = append(words, w.String())
+	}
+	return generated{
+		Password:   password,
+		Sentence:   text,
+		Formats:    sentence.Formats(),
+		Words:      words,
+		Formatters: append([]string{"title", "delimiter:" + delim}, g.args...),
+		Length:     utf8.RuneCountInString(password),
+		Entropy:    g.setupBits + g.entropy.Bits(),
+	}
+}
+
+// generateMinBits returns a formatted password of at least numWords words,
+// extending it until it has at least minBits of entropy, counting only the decisions that are not erased by a later formatter
+func (g *generator) generateMinBits(numWords int, minBits float64) (generated, error) {
+	gen := g.generate(numWords)
+	for gen.Entropy < minBits {
+		if numWords >= maxWords {
+			return gen, fmt.Errorf("a minimum of %.2f bits of entropy is unreachable with the given restrictions", minBits)
+		}
+		numWords++
+		gen = g.generate(numWords)
+	}
+	return gen, nil
+}
+
+// generateCompliant returns a password that complies with the policy, if any, and has at least minBits of entropy.
+// The number of words is adjusted while regenerating, to comply with the length of the policy.
+// The entropy is that of the accepted password, the bits lost by rejecting the passwords that do not comply are not subtracted
+func (g *generator) generateCompliant(numWords int, minBits float64) (generated, error) {
+	if g.policy == nil {
+		return g.generateMinBits(numWords, minBits)
+	}
+	var violation error
+	for attempt := 0; attempt < maxPolicyAttempts; attempt++ {
+		gen, err := g.generateMinBits(numWords, minBits)
+		if err != nil {
+			return gen, err
+		}
+		if violation = g.policy.Check(gen.Password); violation == nil {
+			return gen, nil
+		}
+		if gen.Length < g.policy.MinLength && numWords < maxWords {
+			numWords++
+		} else if g.policy.MaxLength > 0 && gen.Length > g.policy.MaxLength && numWords > 1 {
+			numWords--
+		}
+	}
+	return generated{}, fmt.Errorf("could not generate a password that complies with the policy: %v", violation)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate_ErasedEntropy(t *testing.T) {
+	if err := profaneCmd.PersistentFlags().Set("seed", "entropy"); err != nil {
+		t.Fatal(err)
+	}
+	generate := func(args ...string) generated {
+		g, err := newGenerator(profaneCmd, args)
+		if err != nil {
+			t.Fatalf("unexpected error of %q: %v", args, err)
+		}
+		return g.generate(4)
+	}
+	plain, whispered, screamed := generate(), generate("whisper"), generate("SCREAM")
+	if strings.ToLower(plain.Password) != whispered.Password {
+		t.Fatalf("expected the same words given the same seed, got %q and %q", plain.Password, whispered.Password)
+	}
+	if whispered.Entropy >= plain.Entropy || whispered.Entropy != screamed.Entropy {
+		t.Errorf("expected whisper and SCREAM to erase the title case, got %.2f and %.2f bits of %.2f", whispered.Entropy, screamed.Entropy, plain.Entropy)
+	}
+	if sarcastic := generate("whisper", "/s"); sarcastic.Entropy <= whispered.Entropy {
+		t.Errorf("expected the decisions of /s after whisper to count, got %.2f bits", sarcastic.Entropy)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MikkelHJuul/profaneword"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func obscureFunc(cmd *cobra.Command, args []string) {
+	options, err := optionsOf(cmd)
+	if err != nil {
+		errUseEnd(cmd, err.Error())
+	}
+	flags := cmd.Flags()
+	in, _ := flags.GetString("in")
+	out, _ := flags.GetString("out")
+	inPlace, _ := flags.GetBool("in-place")
+	if inPlace && in == "" {
+		errUseEnd(cmd, "--in-place requires a file given by --in")
+	}
+	if inPlace && out != "" {
+		errUseEnd(cmd, "--in-place cannot be used with --out")
+	}
+	jobs, _ := flags.GetInt("jobs")
+	if jobs < 1 {
+		errUseEnd(cmd, "--jobs must be at least 1")
+	}
+	device := randomDevice(cmd)
+	delim := getDelimiter(cmd.Root(), device, alternateDelimiters)
+	formatterWith := func(device profaneword.RandomDevice) profaneword.Formatter {
+		opts := append([]profaneword.Option{profaneword.WithRandom(device)}, options...)
+		formatter, _ := formatterOf(args, opts, profaneword.DelimiterFormatterWith(delim)) // validated by onlyValidFormatters
+		return formatter
+	}
+	newWriter := func(w io.Writer) io.WriteCloser {
+		return profaneword.NewWriter(w, formatterWith(device))
+	}
+	if jobs > 1 {
+		newWriter = func(w io.Writer) io.WriteCloser {
+			return profaneword.NewParallelWriter(w, func(worker int) profaneword.Formatter {
+				return formatterWith(workerDevice(cmd, worker))
+			}, jobs)
+		}
+	}
+	if inPlace {
+		err = obscureInPlace(in, newWriter)
+	} else {
+		err = obscureFile(cmd, in, out, newWriter)
+	}
+	if err != nil {
+		cmd.PrintErrln(err)
+		os.Exit(1)
+	}
+}
+
+// workerDevice returns the RandomDevice of a worker of a parallel obscure, a LocalCryptoRand,
+// or a SeededRand of the seed and the worker index, if a seed is given
+func workerDevice(cmd *cobra.Command, worker int) profaneword.RandomDevice {
+	pflags := cmd.Root().PersistentFlags()
+	if !pflags.Changed("seed") {
+		return profaneword.NewLocalCryptoRand()
+	}
+	seed, _ := pflags.GetString("seed")
+	return profaneword.NewSeededRand([]byte(fmt.Sprintf("%s#%d", seed, worker)))
+}
+
+// obscureFile formats the file in, or stdin if it is empty, to the file out, or stdout if it is empty.
+// out must not be the same file as in, as creating it would truncate the text before it is read
+func obscureFile(cmd *cobra.Command, in, out string, newWriter func(io.Writer) io.WriteCloser) error {
+	var reader io.Reader = cmd.InOrStdin()
+	if in != "" {
+		file, err := os.Open(in)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if err = notSameFile(file, out); err != nil {
+			return err
+		}
+		reader = file
+	}
+	if out == "" {
+		return obscureTo(newWriter(cmd.OutOrStdout()), reader)
+	}
+	file, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err = obscureTo(newWriter(file), reader); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// notSameFile returns an error if the file named out exists and is the same file as in
+func notSameFile(in *os.File, out string) error {
+	if out == "" {
+		return nil
+	}
+	outInfo, err := os.Stat(out)
+	if err != nil {
+		return nil // out does not exist yet, or os.Create reports the problem
+	}
+	inInfo, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if os.SameFile(inInfo, outInfo) {
+		return fmt.Errorf("--out %s is the same file as --in %s, it would be truncated before it is read", out, in.Name())
+	}
+	return nil
+}
+
+// obscureInPlace formats the file to a temporary file in the same directory, that then replaces the file
+func obscureInPlace(name string, newWriter func(io.Writer) io.WriteCloser) error {
+	in, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+	if err = obscureTo(newWriter(tmp), in); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(info.Mode().Perm()); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("replacing %s: %w", name, err)
+	}
+	return nil
+}
+
+// obscureTo streams the text of r formatted to w
+func obscureTo(writer io.WriteCloser, r io.Reader) error {
+	if _, err := io.Copy(writer, r); err != nil {
+		return err
+	}
+	return writer.Close()
+}
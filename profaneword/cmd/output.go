@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	textOutput = "text"
+	jsonOutput = "json"
+	csvOutput  = "csv"
+)
+
+// output writes generated passwords in one of the output formats
+type output interface {
+	write(generated) error
+	flush() error
+}
+
+func newOutput(cmd *cobra.Command) (output, error) {
+	format, _ := cmd.Flags().GetString("output")
+	switch format {
+	case textOutput:
+		showEntropy, _ := cmd.Flags().GetBool("entropy")
+		return &textWriter{cmd: cmd, showEntropy: showEntropy}, nil
+	case jsonOutput:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetEscapeHTML(false)
+		return &jsonWriter{encoder}, nil
+	case csvOutput:
+		return &csvWriter{Writer: csv.NewWriter(cmd.OutOrStdout())}, nil
+	}
+	return nil, fmt.Errorf("unknown output format: %s", format)
+}
+
+// textWriter prints the password as is, and the entropy if requested
+type textWriter struct {
+	cmd         *cobra.Command
+	showEntropy bool
+}
+
+func (t *textWriter) write(g generated) error {
+	t.cmd.Println(g.Password)
+	if t.showEntropy {
+		t.cmd.Printf("entropy: %.2f bits\n", g.Entropy)
+	}
+	return nil
+}
+
+func (*textWriter) flush() error {
+	return nil
+}
+
+// jsonWriter writes a JSON object per password, on each line
+type jsonWriter struct {
+	*json.Encoder
+}
+
+func (j *jsonWriter) write(g generated) error {
+	return j.Encode(g)
+}
+
+func (*jsonWriter) flush() error {
+	return nil
+}
+
+// csvWriter writes a header, and a record per password; the list fields are JSON arrays
+type csvWriter struct {
+	*csv.Writer
+	wroteHeader bool
+}
+
+var csvHeader = []string{"password", "sentence", "formats", "words", "formatters", "length", "entropy"}
+
+func (c *csvWriter) write(g generated) error {
+	if !c.wroteHeader {
+		if err := c.Write(csvHeader); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+	var lists [3]strings.Builder
+	for i, list := range [3][]string{g.Formats, g.Words, g.Formatters} {
+		encoder := json.NewEncoder(&lists[i])
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(list); err != nil {
+			return err
+		}
+	}
+	return c.Write([]string{
+		g.Password,
+		g.Sentence,
+		strings.TrimSpace(lists[0].String()),
+		strings.TrimSpace(lists[1].String()),
+		strings.TrimSpace(lists[2].String()),
+		strconv.Itoa(g.Length),
+		strconv.FormatFloat(g.Entropy, 'f', 2, 64),
+	})
+}
+
+func (c *csvWriter) flush() error {
+	c.Flush()
+	return c.Error()
+}
@@ -38,18 +38,12 @@ func (a aggregator) getAggregate(word Word) aggregate {
 }
 
 func radixDatabaseAggr(wordToAggregate Word) aggregate {
-	countMap := make(map[string]struct{}, len(wordData))
+	words := wordsOf(wordToAggregate, WEIRD)
 	charsTotal := 0
-	for _, r := range wordData {
-		words := r.GetOfSingle(wordToAggregate, WEIRD)
-		for _, wrd := range words {
-			if _, found := countMap[wrd]; !found {
-				countMap[wrd] = struct{}{}
-				charsTotal += len(wrd)
-			}
-		}
+	for _, wrd := range words {
+		charsTotal += len(wrd)
 	}
-	a := aggregate{count: len(countMap)}
+	a := aggregate{count: len(words)}
 	a.setAverage(charsTotal)
 	return a
 }
@@ -0,0 +1,75 @@
+package profanities
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/MikkelHJuul/profaneword"
+)
+
+// themes are the emoji of the Word types, in order of precedence, for words without an emoji of their own
+var themes = []struct {
+	word  Word
+	emoji string
+}{
+	{POSITIVE, "😇"},
+	{MISSPELL | WEIRD, "🤪"},
+	{EXCL, "🤬"},
+	{END, "🤡"},
+	{DEFAULT, "😤"},
+}
+
+// themeOf returns the emoji of the Word type
+func themeOf(word Word) string {
+	for _, theme := range themes {
+		if word&theme.word != 0 {
+			return theme.emoji
+		}
+	}
+	return themes[len(themes)-1].emoji
+}
+
+// wordTypes is a cache of every single word in wordData, in lowercase, to its Word types
+var wordTypes struct {
+	sync.Once
+	words map[string]Word
+}
+
+// typesOfWords returns every single word in wordData, in lowercase, to the Word types it is given anywhere in the tree.
+// Words of more than one word, fx "Jesus Christ", are left out. The map is shared and must not be modified
+func typesOfWords() map[string]Word {
+	wordTypes.Do(func() {
+		wordTypes.words = make(map[string]Word)
+		var walk func(n *radixWordNode, base string)
+		walk = func(n *radixWordNode, base string) {
+			text := base + n.val
+			if n.word != NONE && strings.IndexFunc(text, unicode.IsSpace) == -1 {
+				wordTypes.words[strings.ToLower(text)] |= n.word
+			}
+			for _, branch := range n.branches {
+				walk(branch, text)
+			}
+		}
+		for _, root := range wordData {
+			walk(root, ``)
+		}
+	})
+	return wordTypes.words
+}
+
+// Emojis returns the base profaneword.EmojiTable extended by every word of the database,
+// such that generated sentences get emoji: a word has the emoji the base finds for it, fx for "horses",
+// or the emoji of its Word type, fx 😇 for POSITIVE words
+func Emojis(base profaneword.EmojiTable) profaneword.EmojiTable {
+	words := typesOfWords()
+	table := make(profaneword.EmojiTable, len(words))
+	for word, types := range words {
+		if emoji, ok := base.Lookup(word); ok {
+			table[word] = emoji
+		} else {
+			table[word] = themeOf(types)
+		}
+	}
+	return table.With(base)
+}
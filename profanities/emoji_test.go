@@ -0,0 +1,44 @@
+package profanities
+
+import (
+	"testing"
+
+	"github.com/MikkelHJuul/profaneword"
+)
+
+func TestEmojis(t *testing.T) {
+	table := Emojis(profaneword.EmojiTable{"fuck": "🖕", "rock": "🪨"})
+	tests := map[string]string{
+		"fucking": "🖕",
+		"rock":    "🪨",
+		"flan":    "🤡",
+	}
+	for word, expected := range tests {
+		if got, ok := table.Lookup(word); !ok || got != expected {
+			t.Errorf("expected %q to be %q, got %q", word, expected, got)
+		}
+	}
+	for word, types := range typesOfWords() {
+		if types == NONE {
+			t.Errorf("expected %q to have a Word type", word)
+		}
+		if _, ok := table[word]; !ok {
+			t.Errorf("expected %q to have an emoji", word)
+		}
+	}
+}
+
+func TestThemeOf(t *testing.T) {
+	tests := map[Word]string{
+		POSITIVE | MISSPELL: "😇",
+		DEFAULT | WEIRD:     "🤪",
+		EXCLS:               "🤬",
+		END:                 "🤡",
+		DEFAULT:             "😤",
+	}
+	for w, expected := range tests {
+		if got := themeOf(w); got != expected {
+			t.Errorf("expected %s to be %q, got %q", w, expected, got)
+		}
+	}
+}
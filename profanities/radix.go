@@ -1,5 +1,7 @@
 package profanities
 
+import "sync"
+
 type radixWordNode struct {
 	val      string
 	branches []*radixWordNode
@@ -33,3 +35,64 @@ func (n *radixWordNode) getWords(base string, words []Word, dissallowedWord Word
 func (n *radixWordNode) GetOfSingle(word, dissallowedWord Word) []string {
 	return n.getWordsOf([]Word{word}, dissallowedWord)[word]
 }
+
+type wordMask struct {
+	word, dissallowedWord Word
+}
+
+// indexedWords are the distinct words of a wordMask, and the Word types of each word
+type indexedWords struct {
+	words []string
+	types []Word
+}
+
+// wordIndex is a cache of the flattened, deduplicated wordData per wordMask
+var wordIndex = struct {
+	sync.Mutex
+	words map[wordMask]indexedWords
+}{words: make(map[wordMask]indexedWords)}
+
+// wordsOf returns every distinct word in wordData of the given Word type, excluding the dissallowed Word type.
+// The radix-tree is flattened only once per combination of word and dissallowedWord,
+// the slice is shared and must not be modified.
+func wordsOf(word, dissallowedWord Word) []string {
+	words, _ := typedWordsOf(word, dissallowedWord)
+	return words
+}
+
+// typedWordsOf returns the words of wordsOf, and the Word types of each word: the types of the nodes of the word,
+// a word found at more than one node has the types of each. The slices are shared and must not be modified.
+func typedWordsOf(word, dissallowedWord Word) ([]string, []Word) {
+	mask := wordMask{word, dissallowedWord}
+	wordIndex.Lock()
+	defer wordIndex.Unlock()
+	if indexed, found := wordIndex.words[mask]; found {
+		return indexed.words, indexed.types
+	}
+	seen := make(map[string]int)
+	var indexed indexedWords
+	var walk func(n *radixWordNode, base string)
+	walk = func(n *radixWordNode, base string) {
+		if n.word&dissallowedWord != 0 {
+			return
+		}
+		text := base + n.val
+		if n.word&word != 0 {
+			if i, found := seen[text]; found {
+				indexed.types[i] |= n.word
+			} else {
+				seen[text] = len(indexed.words)
+				indexed.words = append(indexed.words, text)
+				indexed.types = append(indexed.types, n.word)
+			}
+		}
+		for _, branch := range n.branches {
+			walk(branch, text)
+		}
+	}
+	for _, root := range wordData {
+		walk(root, ``)
+	}
+	wordIndex.words[mask] = indexed
+	return indexed.words, indexed.types
+}
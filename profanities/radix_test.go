@@ -0,0 +1,53 @@
+package profanities
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestWordsOf_Deduplicated(t *testing.T) {
+	words := wordsOf(END, WEIRD)
+	if len(words) == 0 {
+		t.Fatalf("expected words of type END")
+	}
+	seen := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		if _, found := seen[w]; found {
+			t.Errorf("word %q is present more than once", w)
+		}
+		seen[w] = struct{}{}
+	}
+	if again := wordsOf(END, WEIRD); &again[0] != &words[0] {
+		t.Errorf("expected the flattened words to be cached")
+	}
+}
+
+type sequenceRandomDevice struct {
+	next int
+}
+
+func (s *sequenceRandomDevice) Rand() *big.Rat {
+	return big.NewRat(0, 1)
+}
+
+func (s *sequenceRandomDevice) RandMax(max int) int {
+	defer func() { s.next++ }()
+	return s.next % max
+}
+
+func TestProfanitySentencer_getRandomText_Uniform(t *testing.T) {
+	pw := ProfanitySentencer{RandomDevice: &sequenceRandomDevice{}}
+	words := wordsOf(EXCL, WEIRD|MISSPELL)
+	for i, expected := range words {
+		if got, _ := pw.getRandomText(EXCL, WEIRD|MISSPELL); got != expected {
+			t.Fatalf("expected every word to be selectable by index, at %d expected %s, got %s", i, expected, got)
+		}
+	}
+}
+
+func TestProfanitySentencer_getRandomText_NoWords(t *testing.T) {
+	pw := ProfanitySentencer{RandomDevice: &sequenceRandomDevice{}}
+	if got, word := pw.getRandomText(NONE, NONE); got != "" || word != NONE {
+		t.Errorf("expected no word when no word can fit, got %s", got)
+	}
+}
@@ -12,16 +12,57 @@ type sentnc struct {
 }
 
 // Sentence is a linked-list of formattable structures, each with a format string,
-// a word (type) that would fit there, and a pointer to the next part of the Sentence
+// a word (type) that would fit there, and a pointer to the next part of the Sentence.
+// ProfanitySentencer.Sentence records the word it chose for each part, and the Word type of that word
 type Sentence struct {
 	next *Sentence
 	sentnc
+	chosen     string
+	chosenType Word
 }
 
 func (s *Sentence) getPart(word string) string {
 	return fmt.Sprintf(s.format, word)
 }
 
+// Formats returns the format string of each part of the Sentence, in order
+func (s *Sentence) Formats() []string {
+	var formats []string
+	for part := s; part != nil; part = part.next {
+		formats = append(formats, part.format)
+	}
+	return formats
+}
+
+// Words returns the Word types that fit each part of the Sentence, in order; these are the masks of the template,
+// ChosenTypes returns the types of the words chosen
+func (s *Sentence) Words() []Word {
+	var words []Word
+	for part := s; part != nil; part = part.next {
+		words = append(words, part.word)
+	}
+	return words
+}
+
+// Chosen returns the word chosen for each part of the Sentence, in order, by the last call of ProfanitySentencer.Sentence
+func (s *Sentence) Chosen() []string {
+	var chosen []string
+	for part := s; part != nil; part = part.next {
+		chosen = append(chosen, part.chosen)
+	}
+	return chosen
+}
+
+// ChosenTypes returns the Word type of the word chosen for each part of the Sentence, in order,
+// by the last call of ProfanitySentencer.Sentence; NONE if no word was chosen
+func (s *Sentence) ChosenTypes() []Word {
+	var types []Word
+	for part := s; part != nil; part = part.next {
+		types = append(types, part.chosenType)
+	}
+	return types
+}
+
 // Sentencer is any object that can return a string using a Sentence
 type Sentencer interface {
 	Sentence(*Sentence) string
@@ -38,32 +79,31 @@ var _ Sentencer = &ProfanitySentencer{}
 var _ SentenceFetcher = &ProfanitySentencer{}
 
 // Sentence implements the Sentencer interface, using randomized text from the profanities database.
+// The chosen words, and their Word types, are recorded in the Sentence
 func (pw *ProfanitySentencer) Sentence(sentence *Sentence) string {
 	builder := strings.Builder{}
 	for s := sentence; s != nil; s = s.next {
-		text := pw.getRandomText(s.word, pw.dissallowedWord)
-		builder.WriteString(s.getPart(text))
+		s.chosen, s.chosenType = pw.getRandomText(s.word, pw.dissallowedWord)
+		builder.WriteString(s.getPart(s.chosen))
 	}
 	return builder.String()
 }
 
-func (pw *ProfanitySentencer) getRandomText(word, dissallowedWord Word) string {
-	w := ""
-	rLen := len(wordData)
-	for w == "" {
-		base := wordData[pw.RandMax(rLen)]
-		wrds := base.GetOfSingle(word, dissallowedWord)
-		if len(wrds) > 0 {
-			w = wrds[pw.RandMax(len(wrds))]
-		}
+// getRandomText returns a uniformly chosen word of the given Word type, and the Word type of the word,
+// or an empty string and NONE if no word fits
+func (pw *ProfanitySentencer) getRandomText(word, dissallowedWord Word) (string, Word) {
+	words, types := typedWordsOf(word, dissallowedWord)
+	if len(words) == 0 {
+		return "", NONE
 	}
-	return w
+	i := pw.RandMax(len(words))
+	return words[i], types[i]
 }
 
 // NewProfanitySentencer returns a ProfanitySentencer with the default configuration,
-// passing a dissallowedWord to the Sentencer, and using a profaneword.CryptoRand
-func NewProfanitySentencer(dissallowedWord Word) ProfanitySentencer {
-	return ProfanitySentencer{profaneword.CryptoRand{}, dissallowedWord}
+// passing a dissallowedWord to the Sentencer, and using the RandomDevice of the options, default profaneword.CryptoRand
+func NewProfanitySentencer(dissallowedWord Word, opts ...profaneword.Option) ProfanitySentencer {
+	return ProfanitySentencer{profaneword.RandomDeviceOf(opts...), dissallowedWord}
 }
 
 // SentenceFetcher is an interface for an object that returns a Sentence of a given length.
@@ -72,28 +112,16 @@ type SentenceFetcher interface {
 }
 
 // GetSentence implements SentenceFetcher for ProfanitySentencer.
-// GetSentence builds a sentence of arbitrary length by using the internal
-// flatSentence, recursively calling the internal map of flatSentence, and compiling a Sentence from it
+// GetSentence builds a sentence of arbitrary length by choosing the last part among the sentences
+// that may end a Sentence, and prepending parts chosen among all sentences
 func (pw *ProfanitySentencer) GetSentence(numWords int) *Sentence {
-
-	randSent := func() (*Sentence, sent) {
-		idx := pw.RandMax(len(sentences))
-		s := sentences[idx]
-		return &Sentence{sentnc: s.sentnc}, s
-	}
-	var cur *Sentence
-	var s sent
-	pos := notLast
-	for pos&notLast != 0 {
-		cur, s = randSent()
-		pos = s.sentPos
-	}
+	last := lastSentences[pw.RandMax(len(lastSentences))]
+	cur := &Sentence{sentnc: last.sentnc}
 	cur.format = strings.TrimSuffix(cur.format, " ")
 	numWords--
 	for ; numWords > 0; numWords-- {
-		prev := cur
-		cur, s = randSent()
-		cur.next = prev
+		s := sentences[pw.RandMax(len(sentences))]
+		cur = &Sentence{next: cur, sentnc: s.sentnc}
 	}
 	return cur
 }
@@ -159,3 +187,14 @@ var sentences = [...]sent{
 	{sentnc: sentnc{format: `son-of-a-%s `, word: END}},
 	{sentnc: sentnc{format: `the son-of-a-%s `, word: END}},
 }
+
+// lastSentences are the sentences that may be used as the last part of a Sentence
+var lastSentences = func() []sent {
+	var last []sent
+	for _, s := range sentences {
+		if s.sentPos&notLast == 0 {
+			last = append(last, s)
+		}
+	}
+	return last
+}()
@@ -0,0 +1,59 @@
+package profanities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSentence_FormatsWords(t *testing.T) {
+	s := &Sentence{sentnc: sentnc{format: `the %s `, word: all}, next: &Sentence{sentnc: sentnc{format: `%s!`, word: efe}}}
+	if got := s.Formats(); !reflect.DeepEqual(got, []string{`the %s `, `%s!`}) {
+		t.Errorf("unexpected formats: %v", got)
+	}
+	if got := s.Words(); !reflect.DeepEqual(got, []Word{all, efe}) {
+		t.Errorf("unexpected words: %v", got)
+	}
+}
+
+func TestProfanitySentencer_GetSentence(t *testing.T) {
+	pw := ProfanitySentencer{RandomDevice: &sequenceRandomDevice{}}
+	s := pw.GetSentence(3)
+	formats := s.Formats()
+	if len(formats) != 3 {
+		t.Fatalf("expected a sentence of 3 parts, got %d", len(formats))
+	}
+	last := formats[len(formats)-1]
+	for _, sen := range sentences {
+		if sen.sentPos&notLast != 0 && sen.format == last {
+			t.Errorf("expected the last part to be able to end a sentence, got %q", last)
+		}
+	}
+}
+
+func TestWord_String(t *testing.T) {
+	tests := map[Word]string{
+		NONE:                "NONE",
+		START:               "START",
+		DEFAULT:             "START|FILLER",
+		MISSPELL | POSITIVE: "MISSPELL|POSITIVE",
+	}
+	for w, expected := range tests {
+		if got := w.String(); got != expected {
+			t.Errorf("expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestProfanitySentencer_Sentence_ChosenTypes(t *testing.T) {
+	pw := NewProfanitySentencer(WEIRD | MISSPELL)
+	s := &Sentence{sentnc: sentnc{format: `the %s `, word: all}, next: &Sentence{sentnc: sentnc{format: `%s!`, word: EXCL}}}
+	for i := 0; i < 50; i++ {
+		pw.Sentence(s)
+		chosen, types := s.Chosen(), s.ChosenTypes()
+		for j, w := range s.Words() {
+			if types[j]&w == 0 || types[j]&(WEIRD|MISSPELL) != 0 {
+				t.Errorf("expected %q to be of the type %s, and not WEIRD or MISSPELL, got %s", chosen[j], w, types[j])
+			}
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package profanities
 
+import "strings"
+
 // Word is a bitmask for marking Word type in this library, fx, placement in a sentence
 type Word uint8
 
@@ -27,3 +29,20 @@ const (
 	// NONE is the default: there is no word at this radixWordNode
 	NONE Word = 0
 )
+
+// wordNames are the names of each single bit Word, in order
+var wordNames = [...]string{"START", "FILLER", "END", "EXCL", "SPLIT", "MISSPELL", "POSITIVE", "WEIRD"}
+
+// String returns the names of the Word types in the bitmask, '|' separated, fx "START|FILLER"
+func (w Word) String() string {
+	if w == NONE {
+		return "NONE"
+	}
+	var names []string
+	for i, name := range wordNames {
+		if w&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
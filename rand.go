@@ -1,35 +1,190 @@
 package profaneword
 
 import (
+	"bytes"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
 	"math"
 	"math/big"
+	"sync"
 )
 
+// RandomDevice is the original random interface of profaneword, it is kept for compatibility.
+// Any RandomDevice that also implements Source is used through the Source methods,
+// other RandomDevices are adapted using SourceOf.
 type RandomDevice interface {
 	// Rand is a function that returns a random number between 0 and 1
 	Rand() *big.Rat
 	RandMax(max int) int
 }
 
-func getRand(max *big.Int) *big.Int {
-	i, _ := rand.Int(rand.Reader, max)
-	return i
+// Source is a fast random interface, none of the methods allocate
+type Source interface {
+	// Uint64 returns a random uint64
+	Uint64() uint64
+	// Float64 returns a random number in [0, 1)
+	Float64() float64
+	// IntN returns an unbiased random number in [0, n), it panics if n <= 0
+	IntN(n int) int
 }
 
+// SourceOf returns the RandomDevice as a Source, adapting it if it does not implement Source
+func SourceOf(device RandomDevice) Source {
+	if src, ok := device.(Source); ok {
+		return src
+	}
+	return deviceSource{device}
+}
+
+// deviceSource adapts a RandomDevice to a Source
+type deviceSource struct {
+	RandomDevice
+}
+
+// Uint64 builds the number of four uniform 16 bit draws, such that the bound of RandMax fits an int on 32 bit platforms
+func (d deviceSource) Uint64() uint64 {
+	var v uint64
+	for i := 0; i < 4; i++ {
+		v = v<<16 | uint64(d.RandMax(1<<16))
+	}
+	return v
+}
+
+func (d deviceSource) Float64() float64 {
+	f, _ := d.Rand().Float64()
+	return f
+}
+
+func (d deviceSource) IntN(n int) int {
+	return d.RandMax(n)
+}
+
+// uint64n returns an unbiased random number in [0, n) using rejection sampling
+func uint64n(src Source, n uint64) uint64 {
+	if n&(n-1) == 0 { // power of two
+		return src.Uint64() & (n - 1)
+	}
+	limit := math.MaxUint64 - math.MaxUint64%n
+	for {
+		if v := src.Uint64(); v < limit {
+			return v % n
+		}
+	}
+}
+
+func intN(src Source, n int) int {
+	if n <= 0 {
+		panic("profaneword: invalid argument to IntN")
+	}
+	return int(uint64n(src, uint64(n)))
+}
+
+// float64Of returns a float64 in [0, 1) from the 53 most significant bits of v
+func float64Of(v uint64) float64 {
+	return float64(v>>11) / (1 << 53)
+}
+
+// ratOf returns a big.Rat in [0, 1) for the RandomDevice.Rand method of a Source
+func ratOf(src Source) *big.Rat {
+	return big.NewRat(int64(uint64n(src, math.MaxInt64)), math.MaxInt64)
+}
+
+// bufferedSource is a Source that buffers reads of an io.Reader, it is safe for concurrent use
+type bufferedSource struct {
+	mu     sync.Mutex
+	reader io.Reader
+	buf    [512]byte
+	pos    int
+}
+
+// cryptoSource is the buffered crypto/rand source shared by all CryptoRand
+var cryptoSource = &bufferedSource{reader: rand.Reader, pos: 512}
+
+func (b *bufferedSource) Uint64() uint64 {
+	b.mu.Lock()
+	if b.pos+8 > len(b.buf) {
+		if _, err := io.ReadFull(b.reader, b.buf[:]); err != nil {
+			b.mu.Unlock()
+			panic("profaneword: failed to read random bytes: " + err.Error())
+		}
+		b.pos = 0
+	}
+	v := binary.LittleEndian.Uint64(b.buf[b.pos:])
+	b.pos += 8
+	b.mu.Unlock()
+	return v
+}
+
+func (b *bufferedSource) Float64() float64 {
+	return float64Of(b.Uint64())
+}
+
+func (b *bufferedSource) IntN(n int) int {
+	return intN(b, n)
+}
+
+// CryptoRand is a RandomDevice and Source reading crypto/rand through a shared buffer
 type CryptoRand struct{}
 
+var _ RandomDevice = CryptoRand{}
+var _ Source = CryptoRand{}
+
+// Rand returns a random number between 0 and 1
 func (c CryptoRand) Rand() *big.Rat {
-	max := big.NewInt(math.MaxInt64)
-	return big.NewRat(getRand(max).Int64(), max.Int64())
+	return ratOf(cryptoSource)
 }
 
+// RandMax returns a random number in [0, max)
 func (c CryptoRand) RandMax(max int) int {
-	return int(getRand(big.NewInt(int64(max))).Int64())
+	return cryptoSource.IntN(max)
+}
+
+// Uint64 returns a random uint64
+func (c CryptoRand) Uint64() uint64 {
+	return cryptoSource.Uint64()
+}
+
+// Float64 returns a random number in [0, 1)
+func (c CryptoRand) Float64() float64 {
+	return cryptoSource.Float64()
+}
+
+// IntN returns a random number in [0, n)
+func (c CryptoRand) IntN(n int) int {
+	return cryptoSource.IntN(n)
+}
+
+// LocalCryptoRand is a RandomDevice and Source reading crypto/rand through its own buffer,
+// such that concurrent workers, each with their own LocalCryptoRand, do not contend for the shared buffer of CryptoRand
+type LocalCryptoRand struct {
+	*bufferedSource
+}
+
+var _ RandomDevice = LocalCryptoRand{}
+var _ Source = LocalCryptoRand{}
+
+// NewLocalCryptoRand returns a LocalCryptoRand with a new buffer
+func NewLocalCryptoRand() LocalCryptoRand {
+	return LocalCryptoRand{&bufferedSource{reader: rand.Reader, pos: 512}}
+}
+
+// Rand returns a random number between 0 and 1
+func (l LocalCryptoRand) Rand() *big.Rat {
+	return ratOf(l.bufferedSource)
+}
+
+// RandMax returns a random number in [0, max)
+func (l LocalCryptoRand) RandMax(max int) int {
+	return l.IntN(max)
 }
 
 type thresholdRandom struct {
-	Rand      RandomDevice
+	Rand RandomDevice
+	// Threshold is the number a random number in [0, 1) must exceed, 1/2 if it is nil
 	Threshold *big.Rat
 }
 
@@ -46,6 +201,116 @@ func newRandomFormatter(device RandomDevice, threshold *big.Rat) thresholdRandom
 	}
 }
 
-func newFiftyFifty() thresholdRandom {
-	return newRandomFormatter(nil, nil)
+// threshold returns the Threshold as a float64, it is converted on each call such that a Threshold changed in place is used
+func (t thresholdRandom) threshold() float64 {
+	if t.Threshold == nil {
+		return .5
+	}
+	f, _ := t.Threshold.Float64()
+	return f
+}
+
+// SeededRand is a deterministic RandomDevice, the same seed always gives the same sequence of random numbers.
+// It is an HMAC-DRBG (NIST SP 800-90A) using SHA-256, instantiated from the seed.
+type SeededRand struct {
+	mac   hash.Hash // mac is keyed with the current key of the HMAC-DRBG
+	v     []byte
+	block [seededBlockSize]byte
+	pos   int
+}
+
+var _ RandomDevice = &SeededRand{}
+var _ Source = &SeededRand{}
+
+// seededBlockSize is the number of bytes generated between each update of the SeededRand state
+const seededBlockSize = 32 * sha256.Size
+
+// NewSeededRand returns a SeededRand instantiated from the given seed
+func NewSeededRand(seed []byte) *SeededRand {
+	s := &SeededRand{
+		mac: hmac.New(sha256.New, make([]byte, sha256.Size)),
+		v:   bytes.Repeat([]byte{1}, sha256.Size),
+		pos: seededBlockSize,
+	}
+	s.update(seed)
+	return s
+}
+
+func (s *SeededRand) hmac(data ...[]byte) []byte {
+	s.mac.Reset()
+	for _, d := range data {
+		s.mac.Write(d)
+	}
+	return s.mac.Sum(nil)
+}
+
+func (s *SeededRand) rekey(data ...[]byte) {
+	s.mac = hmac.New(sha256.New, s.hmac(data...))
+}
+
+func (s *SeededRand) update(data []byte) {
+	s.rekey(s.v, []byte{0}, data)
+	s.v = s.hmac(s.v)
+	if len(data) > 0 {
+		s.rekey(s.v, []byte{1}, data)
+		s.v = s.hmac(s.v)
+	}
+}
+
+// generate fills the block with new random bytes
+func (s *SeededRand) generate() {
+	for i := 0; i < seededBlockSize; i += sha256.Size {
+		s.mac.Reset()
+		s.mac.Write(s.v)
+		s.v = s.mac.Sum(s.v[:0])
+		copy(s.block[i:], s.v)
+	}
+	s.update(nil)
+	s.pos = 0
+}
+
+// Read fills p with deterministic random bytes, it never returns an error
+func (s *SeededRand) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if s.pos == seededBlockSize {
+			s.generate()
+		}
+		c := copy(p[n:], s.block[s.pos:])
+		s.pos += c
+		n += c
+	}
+	return n, nil
+}
+
+// Uint64 returns a random uint64
+func (s *SeededRand) Uint64() uint64 {
+	if s.pos+8 > seededBlockSize {
+		var b [8]byte
+		_, _ = s.Read(b[:])
+		return binary.BigEndian.Uint64(b[:])
+	}
+	v := binary.BigEndian.Uint64(s.block[s.pos:])
+	s.pos += 8
+	return v
+}
+
+// Float64 returns a random number in [0, 1)
+func (s *SeededRand) Float64() float64 {
+	return float64Of(s.Uint64())
+}
+
+// IntN returns an unbiased random number in [0, n), it panics if n <= 0
+func (s *SeededRand) IntN(n int) int {
+	return intN(s, n)
+}
+
+// Rand returns a random number between 0 and 1
+func (s *SeededRand) Rand() *big.Rat {
+	return ratOf(s)
+}
+
+// RandMax returns a random number in [0, max), it panics if max <= 0
+func (s *SeededRand) RandMax(max int) int {
+	return intN(s, max)
 }
@@ -0,0 +1,63 @@
+package profaneword
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSeededRand_Deterministic(t *testing.T) {
+	one, other := NewSeededRand([]byte("seed")), NewSeededRand([]byte("seed"))
+	for i := 1; i < 1000; i++ {
+		if a, b := one.RandMax(i), other.RandMax(i); a != b {
+			t.Fatalf("expected the same sequence for the same seed, got %d and %d", a, b)
+		}
+		if a, b := one.Rand(), other.Rand(); a.Cmp(b) != 0 {
+			t.Fatalf("expected the same sequence for the same seed, got %v and %v", a, b)
+		}
+	}
+}
+
+func TestSeededRand_Range(t *testing.T) {
+	s := NewSeededRand([]byte("range"))
+	zero, one := new(big.Rat), big.NewRat(1, 1)
+	for i := 1; i < 1000; i++ {
+		if got := s.RandMax(i); got < 0 || got >= i {
+			t.Errorf("RandMax(%d) out of range: %d", i, got)
+		}
+		if got := s.Rand(); got.Cmp(zero) < 0 || got.Cmp(one) >= 0 {
+			t.Errorf("Rand out of range: %v", got)
+		}
+	}
+}
+
+func TestSeededRand_SeedsDiffer(t *testing.T) {
+	a, b := make([]byte, 64), make([]byte, 64)
+	_, _ = NewSeededRand([]byte("one")).Read(a)
+	_, _ = NewSeededRand([]byte("two")).Read(b)
+	if string(a) == string(b) {
+		t.Errorf("expected different seeds to give different output")
+	}
+}
+
+func TestSeededRand_Formatters(t *testing.T) {
+	format := func() string {
+		opts := []Option{WithRandom(NewSeededRand([]byte("golden")))}
+		mf := MultiFormatter{}
+		mf.With(Uber1337Formatter(opts...))
+		mf.With(NewSarcasticFormatter(opts...))
+		mf.With(NewShuffleFormatter(opts...))
+		return mf.Format("the quick brown fox jumps over the lazy dog")
+	}
+	if a, b := format(), format(); a != b {
+		t.Errorf("expected the same seed to format the same, got %s and %s", a, b)
+	}
+}
+
+func TestSourceOf_Uint64(t *testing.T) {
+	if got := SourceOf(maxRandomDevice{}).Uint64(); got != 1<<64-1 {
+		t.Errorf("expected the largest draws to give the largest uint64, got %x", got)
+	}
+	if got := SourceOf(zeroRandomDevice(0)).Uint64(); got != 0 {
+		t.Errorf("expected the smallest draws to give 0, got %x", got)
+	}
+}
@@ -0,0 +1,147 @@
+package profaneword
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Factory returns a new Formatter of the parameter and the options.
+// The parameter is given after the name as name:param, it is empty if not given
+type Factory func(param string, opts ...Option) (Formatter, error)
+
+// Flags describe how a registered Formatter formats
+type Flags uint
+
+const (
+	// PerCharacter is a Formatter that formats each character on its own, such that it can be applied randomly per character
+	PerCharacter Flags = 1 << iota
+	// Unsafe is a Formatter that makes passwords weaker, fx by replacing the words with a few known words
+	Unsafe
+)
+
+// Registration is a Formatter registered by name
+type Registration struct {
+	Name        string
+	Description string
+	Factory     Factory
+	Flags       Flags
+}
+
+var registry = struct {
+	sync.RWMutex
+	byName map[string]Registration
+	names  []string
+}{byName: map[string]Registration{}}
+
+// reservedNames are the names of the combinators of the profaneword command, that apply to other formatters
+var reservedNames = []string{"random", "randomly", "perword"}
+
+// nameSeparators are the characters that separate names in a formatter expression
+const nameSeparators = " \t\n(),|:"
+
+// Register registers the Factory of a Formatter by name, such that the profaneword command can use it.
+// Register panics if the name is registered already, is reserved, or contains spaces or any of "(),|:"
+func Register(name, description string, factory Factory, flags Flags) {
+	if name == "" || strings.ContainsAny(name, nameSeparators) {
+		panic(fmt.Sprintf("profaneword: invalid formatter name %q", name))
+	}
+	for _, reserved := range reservedNames {
+		if name == reserved {
+			panic(fmt.Sprintf("profaneword: the formatter name %q is reserved", name))
+		}
+	}
+	if factory == nil {
+		panic("profaneword: Register factory is nil for " + name)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.byName[name]; dup {
+		panic(fmt.Sprintf("profaneword: Register called twice for %q", name))
+	}
+	registry.byName[name] = Registration{Name: name, Description: description, Factory: factory, Flags: flags}
+	registry.names = append(registry.names, name)
+}
+
+// unregister removes the Formatter registered by the name, it is used by tests to clean up after Register
+func unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.byName[name]; !ok {
+		return
+	}
+	delete(registry.byName, name)
+	for i, registered := range registry.names {
+		if registered == name {
+			registry.names = append(registry.names[:i:i], registry.names[i+1:]...)
+			break
+		}
+	}
+}
+
+// Lookup returns the Registration of the Formatter registered by the name
+func Lookup(name string) (Registration, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	reg, ok := registry.byName[name]
+	return reg, ok
+}
+
+// Registered returns the Registrations of all Formatters, in the order they were registered
+func Registered() []Registration {
+	registry.RLock()
+	defer registry.RUnlock()
+	regs := make([]Registration, len(registry.names))
+	for i, name := range registry.names {
+		regs[i] = registry.byName[name]
+	}
+	return regs
+}
+
+// errNoParameter is the error of a Factory of Plain given a parameter
+var errNoParameter = errors.New("takes no parameter")
+
+// Plain returns a Factory of a Formatter constructor, that takes no parameter
+func Plain(constructor func(...Option) Formatter) Factory {
+	return func(param string, opts ...Option) (Formatter, error) {
+		if param != "" {
+			return nil, errNoParameter
+		}
+		return constructor(opts...), nil
+	}
+}
+
+// fatFingerFactory is the Factory of NewFatFingerFormatter, the parameter is the name of a KeyboardLayout
+func fatFingerFactory(param string, opts ...Option) (Formatter, error) {
+	if param == "" {
+		return NewFatFingerFormatter(opts...), nil
+	}
+	layout, ok := KeyboardLayoutByName(param)
+	if !ok {
+		return nil, fmt.Errorf("unknown keyboard layout %q, expected one of %s", param, LayoutNames())
+	}
+	return NewFatFingerFormatter(append(opts[:len(opts):len(opts)], WithKeyboardLayout(layout))...), nil
+}
+
+func init() {
+	Register("1337", "output formatted as 1337-speak", Plain(L337Formatter), PerCharacter)
+	Register("uber1337", "output formatted with an extended 1337 alphabet", Plain(Uber1337Formatter), PerCharacter)
+	Register("fat", "output some t3xt wifth fat fringers, fat:<layout> types on "+LayoutNames(), fatFingerFactory, PerCharacter)
+	Register("fst", "otput sme tet writen wit haste", Plain(NewFastFingerFormatter), PerCharacter)
+	Register("esrever", "desrever tuptuo, per word", Plain(NewWordReversingFormatter), 0)
+	Register("shuffle", "tuoput si ffudlehs", Plain(NewShuffleFormatter), 0)
+	Register("SCREAM", "OUTPUT IS UPPERCASE", Plain(NewUppercaseFormatter), PerCharacter)
+	Register("whisper", "output is lowercased", Plain(NewLowercaseFormatter), PerCharacter)
+	Register("swear", "output cartoonish #%$@!!", Plain(NewSwearFormatter), PerCharacter)
+	Register("studder", "o-o-output s-s-s-studdering t-text", Plain(NewStudderFormatter), 0)
+	Register("horse", "just output horse-related words in stead", Plain(NewHorseFormatter), Unsafe)
+	Register("emoji", "output horse 🐎 and poop 💩 with emoji, emoji:replace replaces the words in stead", emojiFactory, 0)
+	Register("homoglyph", "output with lookalike lеttеrs of other scripts, homoglyph:<printable|bmp|single|any> sets the strictness", homoglyphFactory, PerCharacter)
+	Register("zalgo", "o̴̹u̞͐t͎̅p̙ͮu̡̍t̰̿ stacked with combining marks, zalgo:p sets the probability of each mark, fx zalgo:0.8", zalgoFactory, PerCharacter)
+	Register("nato", "output spelled out in the NATO phonetic alphabet, to be read aloud", Plain(NewNATOFormatter), 0)
+	Register("morse", "-- --- .-. ... . / -.-. --- -.. .", Plain(NewMorseFormatter), 0)
+	Register("braille", "⠕⠥⠞⠏⠥⠞ ⠊⠝ ⠃⠗⠁⠊⠇⠇⠑", Plain(NewBrailleFormatter), 0)
+	Register("piglatin", "outputway inway igpay atinlay", Plain(NewPigLatinFormatter), 0)
+	Register("/s", "sARcaSTiC OUtpUt", Plain(NewSarcasticFormatter), PerCharacter)
+}
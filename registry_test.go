@@ -0,0 +1,83 @@
+package profaneword
+
+import (
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	Register("test-unit", "returns the text as is", Plain(func(...Option) Formatter { return UnitFormatter{} }), PerCharacter)
+	t.Cleanup(func() { unregister("test-unit") })
+	reg, ok := Lookup("test-unit")
+	if !ok || reg.Name != "test-unit" || reg.Flags != PerCharacter {
+		t.Fatalf("expected the registered formatter, got %v", reg)
+	}
+	f, err := reg.Factory("")
+	if err != nil || f.Format("asd") != "asd" {
+		t.Errorf("expected the factory to build the formatter, got %v", err)
+	}
+	if _, err = reg.Factory("x"); err != errNoParameter {
+		t.Errorf("expected a plain factory to reject a parameter, got %v", err)
+	}
+	regs := Registered()
+	if regs[0].Name != "1337" || regs[len(regs)-1].Name != "test-unit" {
+		t.Errorf("expected the registrations in the order they were registered")
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	before := len(Registered())
+	Register("test-gone", "", Plain(func(...Option) Formatter { return UnitFormatter{} }), 0)
+	unregister("test-gone")
+	if _, ok := Lookup("test-gone"); ok || len(Registered()) != before {
+		t.Errorf("expected the formatter to be unregistered")
+	}
+}
+
+func TestRegister_panics(t *testing.T) {
+	factory := Plain(NewSarcasticFormatter)
+	tests := map[string]func(){
+		"duplicate":      func() { Register("/s", "", factory, 0) },
+		"reserved":       func() { Register("randomly", "", factory, 0) },
+		"empty":          func() { Register("", "", factory, 0) },
+		"separator":      func() { Register("a|b", "", factory, 0) },
+		"parameter":      func() { Register("a:b", "", factory, 0) },
+		"nil factory":    func() { Register("nil", "", nil, 0) },
+		"space in names": func() { Register("a b", "", factory, 0) },
+	}
+	for name, register := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected Register to panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
+
+func TestRegistered_builtins(t *testing.T) {
+	for _, reg := range Registered() {
+		f, err := reg.Factory("", WithRandom(NewSeededRand([]byte(reg.Name))))
+		if err != nil || f == nil {
+			t.Errorf("%s: expected a formatter, got %v", reg.Name, err)
+			continue
+		}
+		_ = f.Format("a quick brown fox")
+	}
+}
+
+func TestFatFingerFactory_options(t *testing.T) {
+	opts := make([]Option, 1, 2)
+	opts[0] = WithRandom(NewSeededRand([]byte("fat")))
+	unchanged := func(*options) {}
+	spare := append(opts, unchanged)
+	if _, err := fatFingerFactory("dvorak", opts...); err != nil {
+		t.Fatal(err)
+	}
+	o := &options{}
+	spare[1](o)
+	if o.layout != nil {
+		t.Errorf("expected the layout not to be written into the spare capacity of the options")
+	}
+}
@@ -0,0 +1,135 @@
+package profaneword
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// maxBuffered is the number of bytes a formatting writer holds, waiting for the end of a line,
+// before formatting the text up to the last space instead
+const maxBuffered = 64 * 1024
+
+// formattingWriter formats the text written to it, line by line, before writing it to the underlying io.Writer
+type formattingWriter struct {
+	w   io.Writer
+	f   Formatter
+	buf []byte
+	out []byte
+	err error
+}
+
+// NewWriter returns an io.WriteCloser that formats the text written to it with the Formatter, and writes it to w.
+// The text is formatted a line at a time; line endings, "\n" or "\r\n", are written verbatim and never passed to the Formatter.
+// Very long lines are formatted in parts, split at spaces or tabs, such that the memory used is bounded.
+// Close formats and writes any remaining text, it does not close w
+func NewWriter(w io.Writer, f Formatter) io.WriteCloser {
+	return &formattingWriter{w: w, f: f}
+}
+
+// Write buffers p, and formats and writes every complete line
+func (fw *formattingWriter) Write(p []byte) (int, error) {
+	if fw.err != nil {
+		return 0, fw.err
+	}
+	fw.buf = append(fw.buf, p...)
+	var consumed int
+	fw.out, consumed = appendFormattedLines(fw.out[:0], fw.buf, fw.f)
+	if rest := fw.buf[consumed:]; len(rest) > maxBuffered {
+		cut := partCut(rest)
+		fw.out = append(fw.out, fw.f.Format(string(rest[:cut]))...)
+		consumed += cut
+	}
+	fw.buf = fw.buf[:copy(fw.buf, fw.buf[consumed:])]
+	if len(fw.out) > 0 {
+		_, fw.err = fw.w.Write(fw.out)
+	}
+	if fw.err != nil {
+		return 0, fw.err
+	}
+	return len(p), nil
+}
+
+// appendFormattedLines appends every complete line of the text, formatted by f, to out.
+// The line endings are appended verbatim. It returns out and the number of bytes of text formatted
+func appendFormattedLines(out, text []byte, f Formatter) ([]byte, int) {
+	consumed := 0
+	for {
+		eol := bytes.IndexByte(text[consumed:], '\n')
+		if eol == -1 {
+			return out, consumed
+		}
+		line := text[consumed : consumed+eol+1]
+		content := bytes.TrimSuffix(line[:len(line)-1], []byte{'\r'})
+		out = append(out, f.Format(string(content))...)
+		out = append(out, line[len(content):]...)
+		consumed += len(line)
+	}
+}
+
+// partCut returns where to split the text of an unfinished line: after the last space or tab,
+// or, if there is none, after the last complete rune
+func partCut(text []byte) int {
+	cut := bytes.LastIndexAny(text, " \t") + 1
+	if cut == 0 {
+		cut = len(text)
+		start := cut - 1
+		for start > 0 && cut-start < utf8.UTFMax && !utf8.RuneStart(text[start]) {
+			start--
+		}
+		if !utf8.FullRune(text[start:]) {
+			cut = start
+		}
+	}
+	return cut
+}
+
+// Close formats and writes the remaining text, that did not end with a newline
+func (fw *formattingWriter) Close() error {
+	if fw.err != nil || len(fw.buf) == 0 {
+		return fw.err
+	}
+	_, fw.err = io.WriteString(fw.w, fw.f.Format(string(fw.buf)))
+	fw.buf = fw.buf[:0]
+	return fw.err
+}
+
+// formattingReader reads from an io.Reader, and formats the text through a formattingWriter
+type formattingReader struct {
+	r      io.Reader
+	fw     io.WriteCloser
+	out    bytes.Buffer
+	chunk  []byte
+	err    error
+	closed bool
+}
+
+// NewReader returns an io.Reader that reads from r, and returns the text formatted with the Formatter,
+// in the same way as NewWriter
+func NewReader(r io.Reader, f Formatter) io.Reader {
+	fr := &formattingReader{r: r, chunk: make([]byte, 32*1024)}
+	fr.fw = NewWriter(&fr.out, f)
+	return fr
+}
+
+// Read returns formatted text, reading from the underlying io.Reader when no formatted text is available
+func (fr *formattingReader) Read(p []byte) (int, error) {
+	for fr.out.Len() == 0 && !fr.closed {
+		if fr.err != nil {
+			fr.closed = true
+			if err := fr.fw.Close(); err != nil {
+				return 0, err
+			}
+			break
+		}
+		var n int
+		n, fr.err = fr.r.Read(fr.chunk)
+		if _, err := fr.fw.Write(fr.chunk[:n]); err != nil {
+			return 0, err
+		}
+	}
+	if fr.out.Len() > 0 {
+		return fr.out.Read(p)
+	}
+	return 0, fr.err
+}
@@ -0,0 +1,119 @@
+package profaneword
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// bracketFormatter shows where the text was split, by wrapping each formatted part in brackets
+type bracketFormatter struct{}
+
+func (bracketFormatter) Format(text string) string {
+	return "[" + text + "]"
+}
+
+func TestNewWriter(t *testing.T) {
+	tests := map[string]string{
+		"":                    "",
+		"one":                 "[one]",
+		"one\n":               "[one]\n",
+		"one\r\ntwo\nthree":   "[one]\r\n[two]\n[three]",
+		"\n\r\n":              "[]\n[]\r\n",
+		"a\rb\n":              "[a\rb]\n",
+		"ærlig talt\r\nhej\n": "[ærlig talt]\r\n[hej]\n",
+	}
+	for text, expected := range tests {
+		for _, size := range []int{1, 2, 3, len(text) + 1} {
+			out := bytes.Buffer{}
+			w := NewWriter(&out, bracketFormatter{})
+			for i := 0; i < len(text); i += size {
+				end := i + size
+				if end > len(text) {
+					end = len(text)
+				}
+				if n, err := w.Write([]byte(text[i:end])); err != nil || n != end-i {
+					t.Fatalf("write of %q: wrote %d, err %v", text[i:end], n, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != expected {
+				t.Errorf("%q written %d bytes at a time: expected %q, got %q", text, size, expected, out.String())
+			}
+		}
+	}
+}
+
+func TestNewWriter_longLine(t *testing.T) {
+	words := strings.Repeat("word ", maxBuffered/2)
+	noSpaces := strings.Repeat("ø", maxBuffered)
+	for _, text := range []string{words, noSpaces} {
+		out := bytes.Buffer{}
+		w := NewWriter(&out, bracketFormatter{})
+		for i := 0; i < len(text); i += 1000 {
+			end := i + 1000
+			if end > len(text) {
+				end = len(text)
+			}
+			_, _ = w.Write([]byte(text[i:end]))
+		}
+		if out.Len() == 0 {
+			t.Errorf("expected a long line to be formatted before it ended")
+		}
+		_ = w.Close()
+		parts := strings.Split(strings.Trim(out.String(), "[]"), "][")
+		if len(parts) < 2 || strings.Join(parts, "") != text {
+			t.Errorf("expected the long line to be formatted in parts, got %d parts", len(parts))
+		}
+		for _, part := range parts {
+			if !utf8.ValidString(part) {
+				t.Errorf("a part was split inside a rune: %q", part[len(part)-3:])
+			}
+			if text == words && !strings.HasSuffix(part, " ") && part != parts[len(parts)-1] {
+				t.Errorf("a part was not split at a space: %q", part[len(part)-5:])
+			}
+		}
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
+
+func TestNewWriter_error(t *testing.T) {
+	w := NewWriter(errWriter{}, bracketFormatter{})
+	if _, err := w.Write([]byte("one\n")); err != io.ErrShortWrite {
+		t.Errorf("expected the error of the underlying writer, got %v", err)
+	}
+	if _, err := w.Write([]byte("two\n")); err != io.ErrShortWrite {
+		t.Errorf("expected the error to persist, got %v", err)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	text := "a quick brown\r\nfox\n\njumps"
+	got, err := io.ReadAll(NewReader(strings.NewReader(text), ReversingFormatter{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "nworb kciuq a\r\nxof\n\nspmuj"; string(got) != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func BenchmarkNewWriter(b *testing.B) {
+	text := []byte(obscureInput)
+	formatter := NewWordReversingFormatter()
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		w := NewWriter(io.Discard, formatter)
+		_, _ = w.Write(text)
+		_ = w.Close()
+	}
+}
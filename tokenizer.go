@@ -0,0 +1,132 @@
+package profaneword
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a part of a text, either a word or the separator between words
+type Token struct {
+	Text string
+	// Word is whether the Token is a word, otherwise it is a separator
+	Word bool
+}
+
+// Tokenizer splits a text into Tokens, such that joining the Text of the Tokens returns the original text
+type Tokenizer interface {
+	Tokenize(string) []Token
+}
+
+// tokenizeBy splits the text into runs of runes that are part of a word, or not, as decided by isWord
+func tokenizeBy(text string, isWord func(prev, r, next rune) bool) []Token {
+	var tokens []Token
+	start := 0
+	prev := rune(-1)
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		next, _ := utf8.DecodeRuneInString(text[i+size:])
+		word := isWord(prev, r, next)
+		if i == 0 {
+			tokens = append(tokens, Token{Word: word})
+		} else if word != tokens[len(tokens)-1].Word {
+			tokens[len(tokens)-1].Text = text[start:i]
+			tokens = append(tokens, Token{Word: word})
+			start = i
+		}
+		prev = r
+		i += size
+	}
+	if len(tokens) > 0 {
+		tokens[len(tokens)-1].Text = text[start:]
+	}
+	return tokens
+}
+
+// WhitespaceTokenizer splits the text on whitespace, any run of non-space characters is a word
+type WhitespaceTokenizer struct{}
+
+var _ Tokenizer = WhitespaceTokenizer{}
+
+// Tokenize splits the text into words and runs of whitespace
+func (WhitespaceTokenizer) Tokenize(text string) []Token {
+	return tokenizeBy(text, func(_, r, _ rune) bool {
+		return !unicode.IsSpace(r)
+	})
+}
+
+// WordTokenizer splits the text into words by the word boundaries of Unicode UAX #29, simplified:
+// words are runs of letters, digits, marks and connectors (fx '_'), including apostrophes and periods
+// between letters, like "don't", and commas and periods between digits, like "1,000.5".
+// Punctuation, symbols and whitespace separate words, such that "sex-fucker!" is the words "sex" and "fucker".
+type WordTokenizer struct{}
+
+var _ Tokenizer = WordTokenizer{}
+
+func isWordRune(r rune) bool {
+	return unicode.In(r, unicode.L, unicode.N, unicode.M, unicode.Pc) || r == zwj
+}
+
+func isMidLetter(r rune) bool {
+	switch r {
+	case '\'', '’', '.', ':', '·', '‧', '﹕', '＇', '．', '：':
+		return true
+	}
+	return false
+}
+
+func isMidNum(r rune) bool {
+	switch r {
+	case ',', '.', ';', '\'', '’', '，', '．', '；':
+		return true
+	}
+	return false
+}
+
+// Tokenize splits the text into words and the separators between them
+func (WordTokenizer) Tokenize(text string) []Token {
+	return tokenizeBy(text, func(prev, r, next rune) bool {
+		switch {
+		case isWordRune(r):
+			return true
+		case isMidLetter(r) && unicode.IsLetter(prev) && unicode.IsLetter(next):
+			return true
+		case isMidNum(r) && unicode.IsDigit(prev) && unicode.IsDigit(next):
+			return true
+		}
+		return false
+	})
+}
+
+// RegexpTokenizer splits the text by a regular expression, that matches the words
+type RegexpTokenizer struct {
+	*regexp.Regexp
+}
+
+var _ Tokenizer = RegexpTokenizer{}
+
+// NewRegexpTokenizer returns a RegexpTokenizer of the regular expression, that matches the words
+func NewRegexpTokenizer(expr string) (RegexpTokenizer, error) {
+	re, err := regexp.Compile(expr)
+	return RegexpTokenizer{re}, err
+}
+
+// Tokenize returns each non-empty match as a word, and the text between matches as separators
+func (rt RegexpTokenizer) Tokenize(text string) []Token {
+	var tokens []Token
+	last := 0
+	for _, match := range rt.FindAllStringIndex(text, -1) {
+		if match[0] == match[1] {
+			continue
+		}
+		if match[0] > last {
+			tokens = append(tokens, Token{Text: text[last:match[0]]})
+		}
+		tokens = append(tokens, Token{Text: text[match[0]:match[1]], Word: true})
+		last = match[1]
+	}
+	if last < len(text) {
+		tokens = append(tokens, Token{Text: text[last:]})
+	}
+	return tokens
+}
@@ -0,0 +1,86 @@
+package profaneword
+
+import (
+	"strings"
+	"testing"
+)
+
+func wordTexts(tokens []Token) (words []string) {
+	for _, token := range tokens {
+		if token.Word {
+			words = append(words, token.Text)
+		}
+	}
+	return
+}
+
+func joinTokens(tokens []Token) string {
+	sb := strings.Builder{}
+	for _, token := range tokens {
+		sb.WriteString(token.Text)
+	}
+	return sb.String()
+}
+
+func TestTokenizers(t *testing.T) {
+	regexpTokenizer, err := NewRegexpTokenizer(`[[:alpha:]]+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		tokenizer Tokenizer
+		text      string
+		expected  []string
+	}{
+		{WhitespaceTokenizer{}, "", nil},
+		{WhitespaceTokenizer{}, "sex-fucker! you\tdumb\r\nass", []string{"sex-fucker!", "you", "dumb", "ass"}},
+		{WhitespaceTokenizer{}, "  padded  ", []string{"padded"}},
+		{WordTokenizer{}, "sex-fucker! you\tdumb_ass", []string{"sex", "fucker", "you", "dumb_ass"}},
+		{WordTokenizer{}, "don't pay 1,000.50 for e.g. this.", []string{"don't", "pay", "1,000.50", "for", "e.g", "this"}},
+		{WordTokenizer{}, "Ødelagt été", []string{"Ødelagt", "été"}},
+		{WordTokenizer{}, "?!", nil},
+		{regexpTokenizer, "func(a, b int) {}", []string{"func", "a", "b", "int"}},
+	}
+	for _, test := range tests {
+		tokens := test.tokenizer.Tokenize(test.text)
+		if words := wordTexts(tokens); strings.Join(words, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("%T of %q: expected words %q, got %q", test.tokenizer, test.text, test.expected, words)
+		}
+		if joined := joinTokens(tokens); joined != test.text {
+			t.Errorf("%T of %q: rejoined as %q", test.tokenizer, test.text, joined)
+		}
+	}
+}
+
+func TestPerWordFormattingFormatter_Tokenizer(t *testing.T) {
+	text := "sex-fucker!\tyou  dumb_ass\r\n"
+	tests := map[Tokenizer]string{
+		WhitespaceTokenizer{}: "!rekcuf-xes\tuoy  ssa_bmud\r\n",
+		WordTokenizer{}:       "xes-rekcuf!\tuoy  ssa_bmud\r\n",
+	}
+	for tokenizer, expected := range tests {
+		formatter := NewWordReversingFormatter(WithTokenizer(tokenizer))
+		if got := formatter.Format(text); got != expected {
+			t.Errorf("%T: expected %q, got %q", tokenizer, expected, got)
+		}
+	}
+}
+
+func FuzzTokenizers(f *testing.F) {
+	for _, seed := range []string{"", "sex-fucker! you", "don't 1,000.5", "a\r\nb", "\xff\xfe", "'a'"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		for _, tokenizer := range []Tokenizer{WhitespaceTokenizer{}, WordTokenizer{}} {
+			tokens := tokenizer.Tokenize(text)
+			if joined := joinTokens(tokens); joined != text {
+				t.Errorf("%T of %q: rejoined as %q", tokenizer, text, joined)
+			}
+			for i, token := range tokens {
+				if token.Text == "" || i > 0 && token.Word == tokens[i-1].Word {
+					t.Errorf("%T of %q: tokens are not alternating non-empty words and separators: %v", tokenizer, text, tokens)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package profaneword
+
+import (
+	"math/big"
+	"unicode"
+)
+
+// zalgoMarks are the combining marks stacked by the ZalgoCharFormatter, above, through and below the rune,
+// and the most marks of each position added to a rune
+var zalgoMarks = []struct {
+	marks []rune
+	most  int
+}{
+	{runeRanges(0x0300, 0x0314, 0x031A, 0x031A, 0x033D, 0x0344, 0x0346, 0x0346, 0x034A, 0x034C,
+		0x0350, 0x0352, 0x0357, 0x0357, 0x035B, 0x035B, 0x0363, 0x036F), 8},
+	{runeRanges(0x0315, 0x0315, 0x031B, 0x031B, 0x0321, 0x0322, 0x0327, 0x0328, 0x0334, 0x0338,
+		0x0358, 0x0358, 0x035C, 0x0362), 2},
+	{runeRanges(0x0316, 0x0319, 0x031C, 0x0320, 0x0323, 0x0326, 0x0329, 0x0333, 0x0339, 0x033C,
+		0x0345, 0x0345, 0x0347, 0x0349, 0x034D, 0x034E, 0x0353, 0x0356, 0x0359, 0x035A), 8},
+}
+
+// runeRanges returns the runes of the ranges, given as pairs of the first and last rune
+func runeRanges(pairs ...rune) []rune {
+	var runes []rune
+	for i := 0; i+1 < len(pairs); i += 2 {
+		for r := pairs[i]; r <= pairs[i+1]; r++ {
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
+
+// isZalgoMark reports whether the rune is one of the zalgoMarks
+func isZalgoMark(r rune) bool {
+	for _, position := range zalgoMarks {
+		for _, mark := range position.marks {
+			if r == mark {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// zalgoSkips reports whether the rune gets no marks: spaces, controls and marks
+func zalgoSkips(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsControl(r) || unicode.Is(unicode.M, r)
+}
+
+// ZalgoCharFormatter is a CharFormatter that stacks random combining marks above, through and below each rune.
+// A mark is added while a random number exceeds the Threshold, up to the most marks of the position,
+// thus the intensity is the probability of a mark, like the probability of a RandomlyFormattingCharFormatter
+type ZalgoCharFormatter struct {
+	thresholdRandom
+}
+
+var _ CharFormatter = ZalgoCharFormatter{}
+
+// FormatRune returns the rune followed by random combining marks, spaces, controls and marks are returned as they are
+func (z ZalgoCharFormatter) FormatRune(r rune) []rune {
+	out := []rune{r}
+	if zalgoSkips(r) {
+		return out
+	}
+	for _, position := range zalgoMarks {
+		for i := 0; i < position.most && randAbove(z.Rand, z.threshold()); i++ {
+			out = append(out, position.marks[z.Rand.RandMax(len(position.marks))])
+		}
+	}
+	return out
+}
+
+// NewZalgoFormatter returns a ZalgoCharFormatter, in a CharFormatterDelegatingFormatter, that adds a mark with probability 1/2,
+// or one minus the threshold given by WithThreshold
+func NewZalgoFormatter(opts ...Option) Formatter {
+	o := newOptions(opts)
+	return &CharFormatterDelegatingFormatter{ZalgoCharFormatter{o.thresholdRandom()}}
+}
+
+// zalgoFactory is the Factory of the zalgo Formatter, the parameter is the intensity, a probability as given to ParseProbability
+func zalgoFactory(param string, opts ...Option) (Formatter, error) {
+	if param == "" {
+		return NewZalgoFormatter(opts...), nil
+	}
+	p, err := ParseProbability(param)
+	if err != nil {
+		return nil, err
+	}
+	return NewZalgoFormatter(append(opts[:len(opts):len(opts)], WithThreshold(p.Sub(big.NewRat(1, 1), p)))...), nil
+}
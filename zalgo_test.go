@@ -0,0 +1,65 @@
+package profaneword
+
+import (
+	"math/big"
+	"testing"
+	"unicode"
+)
+
+func TestZalgoCharFormatter_FormatRune(t *testing.T) {
+	device := NewSeededRand([]byte("zalgo"))
+	never := ZalgoCharFormatter{newRandomFormatter(device, big.NewRat(1, 1))}
+	if got := never.FormatRune('a'); len(got) != 1 {
+		t.Errorf("expected no marks at intensity 0, got %q", string(got))
+	}
+	always := ZalgoCharFormatter{newRandomFormatter(device, new(big.Rat))}
+	got := always.FormatRune('a')
+	if len(got) != 1+8+2+8 || got[0] != 'a' {
+		t.Errorf("expected the most marks of each position at intensity 1, got %q", string(got))
+	}
+	for _, mark := range got[1:] {
+		if !unicode.Is(unicode.Mn, mark) || !isZalgoMark(mark) {
+			t.Errorf("expected a combining mark, got %U", mark)
+		}
+	}
+	for _, r := range []rune{' ', '\n', '\u0301'} {
+		if got := always.FormatRune(r); len(got) != 1 {
+			t.Errorf("expected %U to get no marks, got %q", r, string(got))
+		}
+	}
+}
+
+func TestZalgoFormatter(t *testing.T) {
+	formatter, err := zalgoFactory("1/10", WithRandom(NewSeededRand([]byte("zalgo"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if threshold := formatter.(*CharFormatterDelegatingFormatter).CharFormatter.(ZalgoCharFormatter).threshold(); threshold != 0.9 {
+		t.Errorf("expected the intensity to be the probability of a mark, got the threshold %v", threshold)
+	}
+	text := "a quick brown fox jumps over the lazy dog"
+	if got, _, err := Invert(formatter, formatter.Format(text)); err != nil || got != text {
+		t.Errorf("expected the marks to be removed, got %q and %v", got, err)
+	}
+	if _, err = zalgoFactory("3/2"); err == nil {
+		t.Errorf("expected an intensity above 1 to fail")
+	}
+	if formatter = NewZalgoFormatter(WithThreshold(big.NewRat(1, 1))); formatter.Format(text) != text {
+		t.Errorf("expected no marks at intensity 0")
+	}
+}
+
+func TestZalgoFactory_options(t *testing.T) {
+	opts := make([]Option, 1, 2)
+	opts[0] = WithRandom(NewSeededRand([]byte("zalgo")))
+	unchanged := func(*options) {}
+	spare := append(opts, unchanged)
+	if _, err := zalgoFactory("1/10", opts...); err != nil {
+		t.Fatal(err)
+	}
+	o := &options{}
+	spare[1](o)
+	if o.threshold != nil {
+		t.Errorf("expected the threshold not to be written into the spare capacity of the options")
+	}
+}